@@ -3,7 +3,9 @@ package main
 import (
 	"time"
 
+	"github.com/ericogr/chimera-cards/internal/ai"
 	"github.com/ericogr/chimera-cards/internal/config"
+	"github.com/ericogr/chimera-cards/internal/engine"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/hybridname"
 	"github.com/ericogr/chimera-cards/internal/logging"
@@ -34,6 +36,24 @@ func applyPromptTemplates(cfg *config.LoadedConfig) {
 	}
 }
 
+// applyRules makes the configured combat ruleset and synergies the ones
+// used by the engine.
+func applyRules(cfg *config.LoadedConfig) {
+	if cfg == nil {
+		return
+	}
+	engine.SetRules(cfg.Rules)
+	engine.SetSynergies(cfg.Synergies)
+}
+
+// applyAISettings configures the search AI used by expert bots and hints.
+func applyAISettings(cfg *config.LoadedConfig) {
+	if cfg == nil {
+		return
+	}
+	ai.SetTimeBudget(cfg.AITimeBudget)
+}
+
 func createRepositoryOrExit(dbPath string, entities []game.Entity, publicGamesTTL time.Duration) storage.Repository {
 	db, err := storage.OpenDB(dbPath, entities)
 	if err != nil {
@@ -6,13 +6,24 @@ import (
 	"time"
 
 	"github.com/ericogr/chimera-cards/internal/api"
+	"github.com/ericogr/chimera-cards/internal/bot"
 	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/logging"
+	"github.com/ericogr/chimera-cards/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	// Offline subcommands do not need the server environment.
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplay(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		os.Exit(runSimulate(os.Args[2:]))
+	}
+
 	checkEnvVars([]string{constants.EnvSessionSecret, constants.EnvGoogleClientID, constants.EnvGoogleClientSecret, constants.EnvOpenAIAPIKey})
 	// Load entity configuration file (required). Path may be provided via
 	// CHIMERA_CONFIG env var or defaults to ./chimera_config.json in the
@@ -23,6 +34,8 @@ func main() {
 	}
 	cfg := loadConfigOrExit(configPath)
 	applyPromptTemplates(cfg)
+	applyRules(cfg)
+	applyAISettings(cfg)
 
 	// Allow the DB path to be configured via CHIMERA_DB. Default to
 	// a `data/` directory inside the backend module for local development.
@@ -31,12 +44,19 @@ func main() {
 		dbPath = "./data/chimera.db"
 	}
 	repo := createRepositoryOrExit(dbPath, cfg.Entities, cfg.PublicGamesTTL)
-	handler := api.NewGameHandler(repo, cfg.ActionTimeout, cfg.PublicGamesTTL)
+	// The handlers, bots and timeout scanner publish the domain events of
+	// the games on bus; side effects such as the event log and the updates
+	// streamed to clients subscribe to it.
+	bus := events.NewBus()
+	service.SubscribeLog(bus)
+	updates := service.NewPublisher(bus)
+	bot.NewRunner(repo, bus, cfg.ActionTimeout)
+	handler := api.NewGameHandler(repo, cfg.ActionTimeout, cfg.PublicGamesTTL, updates, bus)
 
 	// Worker identity for claim operations (unique per process start)
 	workerID := fmt.Sprintf("%d-%d", os.Getpid(), time.Now().UnixNano())
 
-	startTimeoutScanner(repo, cfg.ActionTimeout, workerID)
+	startTimeoutScanner(repo, bus, cfg.ActionTimeout, workerID)
 	authHandler := api.NewAuthHandler(repo)
 
 	// Create a fresh Gin engine and attach only the desired middleware.
@@ -69,11 +89,17 @@ func main() {
 		protected.POST(constants.RouteGames, handler.CreateGame)
 		protected.POST(constants.RouteGamesJoin, handler.JoinGame)
 		protected.GET(constants.RouteGameByCode, handler.GetGame)
+		protected.GET(constants.RouteGameEvents, handler.StreamGameEvents)
 		protected.POST(constants.RouteGameStart, handler.StartGame)
 		protected.POST(constants.RouteGameEnd, handler.EndGame)
 		protected.POST(constants.RouteGameLeave, handler.LeaveGame)
 		protected.POST(constants.RouteCreateHybrids, handler.CreateHybrids)
 		protected.POST(constants.RouteGameAction, handler.SubmitAction)
+		protected.POST(constants.RouteGameNextReserve, handler.SetNextReserve)
+		protected.POST(constants.RouteGameBots, handler.AddBot)
+		protected.GET(constants.RouteGameHint, handler.GetHint)
+		protected.GET(constants.RouteGameRounds, handler.GetGameRounds)
+		protected.GET(constants.RouteGameReplay, handler.ExportGameReplay)
 		// Player profile: GET returns stats, POST updates display name
 		protected.POST(constants.RoutePlayerStats, handler.UpdatePlayerProfile)
 	}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ericogr/chimera-cards/internal/config"
+	"github.com/ericogr/chimera-cards/internal/replay"
+)
+
+// runReplay implements `chimera-cards replay [-config file] [-v] <file>`:
+// it re-simulates an exported match and reports where the recomputed state
+// diverges from the recorded one. It returns the process exit code: 0 when
+// the match is reproduced exactly, 1 on divergence and 2 on usage errors.
+func runReplay(args []string) int {
+	stdout, stderr := os.Stdout, os.Stderr
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "re-simulate with the entities, rules and synergies from this chimera_config.json instead of the recorded ones")
+	verbose := fs.Bool("v", false, "print the recorded summary of every round")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: chimera-cards replay [-config chimera_config.json] [-v] <replay.json>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+	defer f.Close()
+	r, err := replay.Decode(f)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+
+	var overrides *replay.Overrides
+	if *configPath != "" {
+		cfg, err := config.LoadConfig(*configPath)
+		if err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+			return 2
+		}
+		overrides = &replay.Overrides{Entities: cfg.Entities, Rules: &cfg.Rules, Synergies: cfg.Synergies}
+	}
+
+	rep, err := replay.Simulate(r, overrides)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+
+	fmt.Fprintf(stdout, "Game %s: %d recorded round(s), recorded winner %s\n", r.JoinCode, len(r.Rounds), orNone(r.Result.Winner))
+	if *verbose {
+		for _, rd := range r.Rounds {
+			fmt.Fprintf(stdout, "\n--- Round %d ---\n%s\n", rd.Number, rd.Summary)
+		}
+		fmt.Fprintln(stdout)
+	}
+	fmt.Fprintf(stdout, "Simulated %d round(s): status %s, winner %s\n", rep.RoundsSimulated, rep.Status, orNone(rep.Winner))
+	if rep.Matches() {
+		fmt.Fprintln(stdout, "OK: the replay reproduces the recorded match")
+		return 0
+	}
+	fmt.Fprintf(stdout, "DIVERGED: %d difference(s)\n", len(rep.Divergences))
+	for _, d := range rep.Divergences {
+		fmt.Fprintln(stdout, "  "+d.String())
+	}
+	return 1
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"time"
 
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/logging"
 	"github.com/ericogr/chimera-cards/internal/service"
@@ -13,7 +14,8 @@ func startTimeoutScanner(repo interface {
 	ClaimTimedOutGameIDs(time.Time, int, time.Duration, string) ([]uint, error)
 	GetGameByID(uint) (*game.Game, error)
 	UpdateGame(*game.Game) error
-}, actionTimeout time.Duration, workerID string) {
+	UpdateStatsOnGameEnd(*game.Game, string) error
+}, bus *events.Bus, actionTimeout time.Duration, workerID string) {
 	go func() {
 		ticker := time.NewTicker(5 * time.Second)
 		defer ticker.Stop()
@@ -35,11 +37,9 @@ func startTimeoutScanner(repo interface {
 				}
 				// delegate to service-level handler which encapsulates the
 				// auto-rest and finish logic.
-				_ = service.HandleTimedOutGame(repo.(interface {
-					UpdateGame(*game.Game) error
-					UpdateStatsOnGameEnd(*game.Game, string) error
-					GetGameByID(uint) (*game.Game, error)
-				}), gg, actionTimeout)
+				// The bots play the round it opens once RoundResolved is
+				// published (see bot.Runner).
+				_ = service.HandleTimedOutGame(repo, bus, gg, actionTimeout)
 			}
 		}
 	}()
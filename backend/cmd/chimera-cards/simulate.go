@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/ericogr/chimera-cards/internal/balance"
+	"github.com/ericogr/chimera-cards/internal/config"
+	"github.com/ericogr/chimera-cards/internal/engine"
+)
+
+// runSimulate implements `chimera-cards simulate`: it plays many matches
+// between hybrid builds made from the configured entities and writes the
+// win-rate matrices per entity, skill and hybrid. It returns the process
+// exit code: 0 on success, 1 when the simulation fails and 2 on usage
+// errors.
+func runSimulate(args []string) int {
+	stdout, stderr := os.Stdout, os.Stderr
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "./chimera_config.json", "configuration with the entities, rules and synergies to simulate")
+	matches := fs.Int("matches", balance.DefaultMatches, "number of matches to play")
+	builds := fs.Int("builds", 0, "sample this many hybrid builds instead of using every one")
+	policyList := fs.String("policies", "random,greedy,defensive", "comma-separated action policies matches are played with")
+	seed := fs.Int64("seed", 1, "random seed; the same seed reproduces the same report")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of matches played in parallel")
+	maxRounds := fs.Int("max-rounds", balance.DefaultMaxRounds, "rounds after which a match ends without a winner")
+	format := fs.String("format", "json", "output format: json or csv")
+	out := fs.String("o", "", "output file for json (default stdout) or output directory for csv (required)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: chimera-cards simulate [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || *matches <= 0 || *workers <= 0 || *maxRounds <= 0 {
+		fs.Usage()
+		return 2
+	}
+	if *format != "json" && *format != "csv" {
+		fmt.Fprintln(stderr, "error: -format must be json or csv")
+		return 2
+	}
+	if *format == "csv" && *out == "" {
+		fmt.Fprintln(stderr, "error: -o is required with -format csv")
+		return 2
+	}
+	policies, err := balance.ParsePolicies(*policyList)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 2
+	}
+	engine.SetSynergies(cfg.Synergies)
+
+	rep, err := balance.Run(cfg.Entities, cfg.Synergies, cfg.Rules, balance.Options{
+		Matches:   *matches,
+		Builds:    *builds,
+		Policies:  policies,
+		Seed:      *seed,
+		Workers:   *workers,
+		MaxRounds: *maxRounds,
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "Played %d match(es) between %d build(s), %d without a winner\n", rep.Matches, rep.Builds, rep.Draws)
+
+	if *format == "csv" {
+		err = rep.WriteCSV(*out)
+	} else if *out != "" {
+		var f *os.File
+		if f, err = os.Create(*out); err == nil {
+			err = rep.WriteJSON(f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	} else {
+		err = rep.WriteJSON(stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+package ai
+
+import (
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Candidates lists the actions the player at idx may submit this round
+// under rules: a basic attack and every usable ability against each enemy
+// in play, defend, rest and a switch to each reserve. A stunned hybrid
+// only gets `rest`, which the engine turns into a skipped turn.
+func Candidates(g *game.Game, idx int, rules game.Rules) []Action {
+	p := &g.Players[idx]
+	h := engine.ActiveHybrid(p)
+	if h == nil {
+		return nil
+	}
+	if engine.IsStunned(h, g.RoundCount) {
+		return []Action{{Type: game.PendingActionRest}}
+	}
+	targets := []*int{nil}
+	if enemies := engine.EnemiesInPlay(g, idx); len(enemies) > 1 {
+		targets = targets[:0]
+		for _, i := range enemies {
+			t := i
+			targets = append(targets, &t)
+		}
+	}
+
+	var out []Action
+	for _, t := range targets {
+		out = append(out, Action{Type: game.PendingActionBasicAttack, TargetIndex: t})
+	}
+	out = append(out, Action{Type: game.PendingActionDefend}, Action{Type: game.PendingActionRest})
+	for _, e := range h.BaseEntities {
+		ch := engine.UsableAbility(h, e.ID, rules)
+		if ch == nil || engine.AbilityBlocked(h, ch, rules) != "" {
+			continue
+		}
+		for _, t := range targets {
+			out = append(out, Action{Type: game.PendingActionAbility, EntityID: ch.ID, TargetIndex: t})
+		}
+	}
+	for i := range p.Hybrids {
+		j := i
+		if engine.SwitchTarget(p, &j) != nil {
+			out = append(out, Action{Type: game.PendingActionSwitch, SwitchIndex: &j})
+		}
+	}
+	return out
+}
+
+// rollout is the fixed policy players follow after the searched round:
+// rest when out of Vigor, otherwise attack the first enemy in play.
+func rollout(g *game.Game, idx int) Action {
+	h := engine.ActiveHybrid(&g.Players[idx])
+	if h == nil {
+		return Action{Type: game.PendingActionSkip}
+	}
+	if h.CurrentVIG == 0 {
+		return Action{Type: game.PendingActionRest}
+	}
+	return Action{Type: game.PendingActionBasicAttack}
+}
+
+// Apply stores a as the pending action of the player at idx, as if the
+// player had submitted it.
+func Apply(g *game.Game, idx int, a Action) {
+	p := &g.Players[idx]
+	p.HasSubmittedAction = true
+	p.PendingActionType = a.Type
+	p.PendingActionEntityID = nil
+	if a.Type == game.PendingActionAbility {
+		id := a.EntityID
+		p.PendingActionEntityID = &id
+	}
+	p.PendingSwitchIndex = nil
+	if a.SwitchIndex != nil {
+		j := *a.SwitchIndex
+		p.PendingSwitchIndex = &j
+	}
+	p.PendingTargetIndex = nil
+	if a.TargetIndex != nil {
+		t := *a.TargetIndex
+		p.PendingTargetIndex = &t
+	}
+}
+
+// submitted returns the action the player at idx submitted this round:
+// the inverse of Apply.
+func submitted(g *game.Game, idx int) Action {
+	p := &g.Players[idx]
+	a := Action{Type: p.PendingActionType}
+	if p.PendingActionEntityID != nil {
+		a.EntityID = *p.PendingActionEntityID
+	}
+	if p.PendingSwitchIndex != nil {
+		j := *p.PendingSwitchIndex
+		a.SwitchIndex = &j
+	}
+	if p.PendingTargetIndex != nil {
+		t := *p.PendingTargetIndex
+		a.TargetIndex = &t
+	}
+	return a
+}
+
+// Evaluate scores g for team between -1 (defeat) and 1 (victory). Running
+// matches are scored by the share of HP each side keeps, every hybrid
+// still standing counting for at least half of its weight.
+func Evaluate(g *game.Game, team int) float64 {
+	if g.Status == game.StatusFinished {
+		switch {
+		case g.WinningTeam == nil:
+			return 0
+		case *g.WinningTeam == team:
+			return 1
+		default:
+			return -1
+		}
+	}
+	var mine, theirs, mineMax, theirsMax float64
+	for i := range g.Players {
+		score, weight := 0.0, 0.0
+		for _, h := range g.Players[i].Hybrids {
+			weight++
+			if h.IsDefeated {
+				continue
+			}
+			hp, base := h.CurrentHitPoints, h.BaseHitPoints
+			if !h.Revealed || base <= 0 {
+				hp, base = 1, 1
+			}
+			score += 0.5 + 0.5*float64(hp)/float64(base)
+		}
+		if g.TeamOf(i) == team {
+			mine, mineMax = mine+score, mineMax+weight
+		} else {
+			theirs, theirsMax = theirs+score, theirsMax+weight
+		}
+	}
+	if mineMax == 0 || theirsMax == 0 {
+		return 0
+	}
+	// Keep running matches strictly between the outcomes of finished ones.
+	return 0.9 * (mine/mineMax - theirs/theirsMax)
+}
@@ -0,0 +1,333 @@
+// Package ai chooses actions by search. Every candidate action of a player
+// is played against every simultaneous answer of the enemies on copies of
+// the game resolved with the engine, followed by a few rounds of a simple
+// rollout policy; outcomes are averaged over fresh random seeds until the
+// time budget runs out. The search only sees what the player sees (package
+// view): the enemies' unrevealed reserves are drawn anew from the entity
+// pool on every pass. The player then takes the action with the best
+// expected value against an opponent model that favours the answers that
+// hurt the player most (expectimax over the opponents' choice).
+package ai
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+	"github.com/ericogr/chimera-cards/internal/view"
+)
+
+// Action is a move a player can submit for the current round. Its JSON
+// form matches the action request of the API.
+type Action struct {
+	Type game.PendingActionType `json:"action_type"`
+	// EntityID is the ability used by an `ability` action.
+	EntityID uint `json:"entity_id,omitempty"`
+	// TargetIndex is the enemy player an attack or ability is aimed at
+	// (nil targets the first enemy in play).
+	TargetIndex *int `json:"target_index,omitempty"`
+	// SwitchIndex is the reserve brought in by a `switch` action.
+	SwitchIndex *int `json:"hybrid_index,omitempty"`
+}
+
+// Suggestion is the result of a search.
+type Suggestion struct {
+	Action Action `json:"action"`
+	// Value is the expected outcome of Action for the player's side, from
+	// -1 (certain defeat) to 1 (certain victory).
+	Value float64 `json:"value"`
+	// Simulations is the number of matches played out by the search.
+	Simulations int `json:"simulations"`
+}
+
+// Search parameters used when Options leaves them unset.
+const (
+	DefaultTimeBudget = 250 * time.Millisecond
+	DefaultDepth      = 3
+	// Temperature shapes the opponent model: lower values assume the
+	// enemies find their best answer more often.
+	Temperature = 0.1
+	// maxProfiles caps the joint enemy answers considered in team games.
+	maxProfiles = 64
+)
+
+// timeBudget is the search time used when Options.Budget is zero. It is
+// set once at startup from the configuration.
+var timeBudget = DefaultTimeBudget
+
+// SetTimeBudget sets the default search time.
+func SetTimeBudget(d time.Duration) {
+	if d > 0 {
+		timeBudget = d
+	}
+}
+
+// TimeBudget returns the default search time.
+func TimeBudget() time.Duration { return timeBudget }
+
+// Options tunes a search; zero values pick the defaults.
+type Options struct {
+	// Budget is the time the search may take. At least one simulation of
+	// every action pair is made even when it runs out.
+	Budget time.Duration
+	// Depth is the number of rounds simulated per playout, the first one
+	// included.
+	Depth int
+	// Seed drives the seeds of the simulated rounds (0 picks a random one).
+	Seed int64
+	// Rules replaces the configured ruleset.
+	Rules *game.Rules
+	// Entities is the pool the enemies' unrevealed hybrids are drawn
+	// from. Without one they get the average build of the revealed
+	// hybrids.
+	Entities []game.Entity
+}
+
+// ErrNoAction is returned when the player has no action to choose: the
+// match is not being planned or the player has no hybrid in the arena.
+var ErrNoAction = errors.New("player has no action to choose")
+
+// BestAction searches the best action of the player at idx from the
+// player's projection of g: the enemies' pending actions and chosen
+// replacements are unknown and their unrevealed hybrids are stand-ins
+// built from o.Entities, so the search neither plays nor advises with
+// information the player does not have. Allies that already submitted
+// their action, which their team sees, are held to it; the others follow
+// the rollout policy. g is not modified.
+func BestAction(g *game.Game, idx int, o Options) (Suggestion, error) {
+	if g.Status != game.StatusInProgress || g.Phase != game.PhasePlanning || idx < 0 || idx >= len(g.Players) {
+		return Suggestion{}, ErrNoAction
+	}
+	g = view.ForPlayer(g, idx)
+	rules := engine.RulesFor(g)
+	if o.Rules != nil {
+		rules = *o.Rules
+	}
+	mine := Candidates(g, idx, rules)
+	if len(mine) == 0 {
+		return Suggestion{}, ErrNoAction
+	}
+	budget := o.Budget
+	if budget <= 0 {
+		budget = timeBudget
+	}
+	depth := o.Depth
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	seed := o.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	// Enemies answer with every combination of their candidates; allies
+	// play their submitted action or the rollout policy.
+	team := g.TeamOf(idx)
+	fixed := map[int]Action{}
+	var enemies []int
+	var enemyActions [][]Action
+	for i := range g.Players {
+		if i == idx {
+			continue
+		}
+		if g.TeamOf(i) == team {
+			fixed[i] = rollout(g, i)
+			if g.Players[i].HasSubmittedAction {
+				fixed[i] = submitted(g, i)
+			}
+			continue
+		}
+		if c := Candidates(g, i, rules); len(c) > 0 {
+			enemies = append(enemies, i)
+			enemyActions = append(enemyActions, c)
+		}
+	}
+	profiles := answerProfiles(enemyActions, rng)
+
+	sum := make([][]float64, len(mine))
+	for a := range sum {
+		sum[a] = make([]float64, len(profiles))
+	}
+	deadline := time.Now().Add(budget)
+	passes, sims := 0, 0
+	for passes == 0 || time.Now().Before(deadline) {
+		// Every action pair of a pass meets the same stand-ins.
+		world := g.Clone()
+		standIns(world, team, o.Entities, rules, rng)
+		for a := range mine {
+			for b, prof := range profiles {
+				sim := world.Clone()
+				Apply(sim, idx, mine[a])
+				for k, i := range enemies {
+					Apply(sim, i, prof[k])
+				}
+				for i, act := range fixed {
+					Apply(sim, i, act)
+				}
+				sum[a][b] += playout(sim, team, depth, rules, rng)
+				sims++
+			}
+		}
+		passes++
+	}
+
+	// Opponent model: a softmax over how well each answer does against
+	// the player's actions on average.
+	weights := make([]float64, len(profiles))
+	total := 0.0
+	for b := range profiles {
+		avg := 0.0
+		for a := range mine {
+			avg += sum[a][b]
+		}
+		avg /= float64(len(mine) * passes)
+		weights[b] = math.Exp(-avg / Temperature)
+		total += weights[b]
+	}
+	best := Suggestion{Value: math.Inf(-1), Simulations: sims}
+	for a := range mine {
+		v := 0.0
+		for b := range profiles {
+			v += weights[b] / total * sum[a][b] / float64(passes)
+		}
+		if v > best.Value {
+			best.Action, best.Value = mine[a], v
+		}
+	}
+	return best, nil
+}
+
+// answerProfiles returns the joint answers of the enemies: one action per
+// enemy, in the order of actions. Large products are sampled.
+func answerProfiles(actions [][]Action, rng *rand.Rand) [][]Action {
+	n := 1
+	for _, c := range actions {
+		n *= len(c)
+	}
+	if n > maxProfiles {
+		out := make([][]Action, maxProfiles)
+		for p := range out {
+			out[p] = make([]Action, len(actions))
+			for k, c := range actions {
+				out[p][k] = c[rng.Intn(len(c))]
+			}
+		}
+		return out
+	}
+	out := [][]Action{{}}
+	for _, c := range actions {
+		next := make([][]Action, 0, len(out)*len(c))
+		for _, prefix := range out {
+			for _, a := range c {
+				next = append(next, append(append([]Action(nil), prefix...), a))
+			}
+		}
+		out = next
+	}
+	return out
+}
+
+// playout resolves the first round of sim, then up to depth-1 more with
+// every player following the rollout policy, and evaluates the result for
+// team.
+func playout(sim *game.Game, team, depth int, rules game.Rules, rng *rand.Rand) float64 {
+	for d := 0; ; d++ {
+		sim.RNGSeed = rng.Int63() | 1
+		engine.ResolveRoundWithRules(sim, rules)
+		if sim.Status != game.StatusInProgress || d+1 >= depth {
+			break
+		}
+		for i := range sim.Players {
+			Apply(sim, i, rollout(sim, i))
+		}
+	}
+	return Evaluate(sim, team)
+}
+
+// standIns replaces the enemies' unrevealed hybrids of g, blank in the
+// projection of a player of team, with hybrids of two or three entities
+// drawn from pool that their owner does not already use. Without a pool
+// they get the average build of the revealed hybrids.
+func standIns(g *game.Game, team int, pool []game.Entity, rules game.Rules, rng *rand.Rand) {
+	avg := averageBuild(g)
+	for i := range g.Players {
+		if g.TeamOf(i) == team {
+			continue
+		}
+		p := &g.Players[i]
+		used := map[uint]bool{}
+		for _, h := range p.Hybrids {
+			for _, e := range h.BaseEntities {
+				used[e.ID] = true
+			}
+		}
+		for j := range p.Hybrids {
+			if p.Hybrids[j].Revealed {
+				continue
+			}
+			var free []game.Entity
+			for _, e := range pool {
+				if !used[e.ID] {
+					free = append(free, e)
+				}
+			}
+			h := avg
+			if len(free) >= 2 {
+				rng.Shuffle(len(free), func(a, b int) { free[a], free[b] = free[b], free[a] })
+				size := 2
+				if len(free) > 2 && rng.Intn(2) == 1 {
+					size = 3
+				}
+				picked := free[:size]
+				for _, e := range picked {
+					used[e.ID] = true
+				}
+				h = engine.BuildHybrid(picked, picked[rng.Intn(len(picked))].ID)
+			}
+			h.ID, h.PlayerID = p.Hybrids[j].ID, p.Hybrids[j].PlayerID
+			h.CurrentHitPoints = h.BaseHitPoints
+			h.CurrentAttack = h.BaseAttack
+			h.CurrentDefense = h.BaseDefense
+			h.CurrentAgility = h.BaseAgility
+			h.CurrentEnergy = h.BaseEnergy
+			h.BaseVIG = rules.BaseVigor
+			h.CurrentVIG = h.BaseVIG
+			p.Hybrids[j] = h
+		}
+	}
+}
+
+// averageBuild returns a hybrid with the average base stats of the
+// revealed hybrids of g, without abilities.
+func averageBuild(g *game.Game) game.Hybrid {
+	var h game.Hybrid
+	n := 0
+	for i := range g.Players {
+		for _, r := range g.Players[i].Hybrids {
+			if !r.Revealed {
+				continue
+			}
+			h.BaseHitPoints += r.BaseHitPoints
+			h.BaseAttack += r.BaseAttack
+			h.BaseDefense += r.BaseDefense
+			h.BaseAgility += r.BaseAgility
+			h.BaseEnergy += r.BaseEnergy
+			n++
+		}
+	}
+	if n > 0 {
+		h.BaseHitPoints /= n
+		h.BaseAttack /= n
+		h.BaseDefense /= n
+		h.BaseAgility /= n
+		h.BaseEnergy /= n
+	}
+	if h.BaseEnergy < 1 {
+		h.BaseEnergy = 1
+	}
+	return h
+}
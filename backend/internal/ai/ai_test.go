@@ -0,0 +1,140 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func duel(myAtk, enemyHP int) *game.Game {
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "A", Hybrids: []game.Hybrid{{Name: "HA", BaseHitPoints: 10, BaseAttack: myAtk, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+		{PlayerName: "B", Hybrids: []game.Hybrid{{Name: "HB", BaseHitPoints: enemyHP, BaseAttack: 8, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+	}}
+	engine.PrepareMatch(g)
+	g.Status = game.StatusInProgress
+	return g
+}
+
+func TestBestAction_TakesTheWinningAttack(t *testing.T) {
+	g := duel(6, 3)
+	s, err := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Action.Type != game.PendingActionBasicAttack {
+		t.Fatalf("expected the finishing basic attack, got %+v", s.Action)
+	}
+	if s.Value < 0.99 || s.Simulations == 0 {
+		t.Fatalf("expected a certain win, got value %.2f after %d simulations", s.Value, s.Simulations)
+	}
+}
+
+func TestBestAction_IgnoresPendingActionsAndKeepsGame(t *testing.T) {
+	g := duel(4, 10)
+	g.Players[1].HasSubmittedAction = true
+	g.Players[1].PendingActionType = game.PendingActionDefend
+	before := g.Clone()
+
+	s1, err := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(before, g) {
+		t.Fatalf("BestAction modified the game")
+	}
+	g.Players[1].PendingActionType = game.PendingActionRest
+	s2, _ := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 7})
+	if !reflect.DeepEqual(s1, s2) {
+		t.Fatalf("the opponent's pending action changed the suggestion: %+v vs %+v", s1, s2)
+	}
+}
+
+func TestCandidates_TeamTargets(t *testing.T) {
+	g := &game.Game{Mode: game.ModeTeams, Players: []game.Player{
+		{PlayerName: "A", Team: 0, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 5, BaseEnergy: 1}, {BaseHitPoints: 10, BaseAttack: 5, BaseEnergy: 1}}},
+		{PlayerName: "B", Team: 1, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 5, BaseEnergy: 1}}},
+		{PlayerName: "C", Team: 0, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 5, BaseEnergy: 1}}},
+		{PlayerName: "D", Team: 1, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 5, BaseEnergy: 1}}},
+	}}
+	engine.PrepareMatch(g)
+	g.Status = game.StatusInProgress
+
+	var targets []int
+	switches := 0
+	for _, a := range Candidates(g, 0, engine.CurrentRules()) {
+		switch a.Type {
+		case game.PendingActionBasicAttack:
+			targets = append(targets, *a.TargetIndex)
+		case game.PendingActionSwitch:
+			switches++
+		}
+	}
+	if !reflect.DeepEqual(targets, []int{1, 3}) || switches != 1 {
+		t.Fatalf("expected attacks on players 1 and 3 and one switch, got %v and %d", targets, switches)
+	}
+	if _, err := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 3, Depth: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBestAction_HoldsAlliesToTheirSubmittedAction(t *testing.T) {
+	g := &game.Game{Mode: game.ModeTeams, Players: []game.Player{
+		{PlayerName: "A", Team: 0, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 4, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+		{PlayerName: "B", Team: 1, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 4, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+		{PlayerName: "C", Team: 0, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 9, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+		{PlayerName: "D", Team: 1, Hybrids: []game.Hybrid{{BaseHitPoints: 10, BaseAttack: 4, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1}}},
+	}}
+	engine.PrepareMatch(g)
+	g.Status = game.StatusInProgress
+	g.Players[2].HasSubmittedAction = true
+	g.Players[2].PendingActionType = game.PendingActionRest
+
+	resting, err := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 5, Depth: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g.Players[2].PendingActionType = game.PendingActionBasicAttack
+	attacking, _ := BestAction(g, 0, Options{Budget: time.Nanosecond, Seed: 5, Depth: 1})
+	if attacking.Value <= resting.Value {
+		t.Fatalf("expected the ally's attack to improve the outlook: %.3f vs %.3f", attacking.Value, resting.Value)
+	}
+}
+
+func TestBestAction_IgnoresUnrevealedEnemyReserves(t *testing.T) {
+	pool := []game.Entity{
+		{Name: "Lion", HitPoints: 6, Attack: 4, Defense: 2, Agility: 3, Energy: 1},
+		{Name: "Raven", HitPoints: 4, Attack: 2, Defense: 1, Agility: 6, Energy: 1},
+		{Name: "Turtle", HitPoints: 8, Attack: 1, Defense: 5, Agility: 1, Energy: 1},
+		{Name: "Wolf", HitPoints: 5, Attack: 4, Defense: 2, Agility: 5, Energy: 1},
+	}
+	for i := range pool {
+		pool[i].ID = uint(i + 1)
+	}
+	g := duel(6, 3)
+	g.Players[1].Hybrids = append(g.Players[1].Hybrids,
+		game.Hybrid{Name: "HB2", BaseHitPoints: 12, BaseAttack: 5, BaseDefense: 2, BaseAgility: 5, BaseEnergy: 1},
+		game.Hybrid{Name: "HB3", BaseHitPoints: 2, BaseAttack: 1, BaseDefense: 1, BaseAgility: 1, BaseEnergy: 1})
+	engine.PrepareMatch(g)
+
+	o := Options{Budget: time.Nanosecond, Seed: 11, Depth: 3, Entities: pool}
+	s1, err := BestAction(g, 0, o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := &g.Players[1].Hybrids[1]
+	r.BaseHitPoints, r.CurrentHitPoints, r.BaseAttack, r.CurrentAttack = 40, 40, 20, 20
+	s2, _ := BestAction(g, 0, o)
+	if !reflect.DeepEqual(s1, s2) {
+		t.Fatalf("the enemy's hidden reserve changed the suggestion: %+v vs %+v", s1, s2)
+	}
+	g.Players[1].NextReserveIndex = new(int)
+	*g.Players[1].NextReserveIndex = 2
+	s3, _ := BestAction(g, 0, o)
+	if !reflect.DeepEqual(s1, s3) {
+		t.Fatalf("the enemy's chosen replacement changed the suggestion: %+v vs %+v", s1, s3)
+	}
+}
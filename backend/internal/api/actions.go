@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/engine"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/service"
 
@@ -13,6 +14,13 @@ import (
 type ActionRequest struct {
 	ActionType string `json:"action_type"`
 	EntityID   uint   `json:"entity_id"`
+	// HybridIndex selects the reserve for a `switch` action; when omitted
+	// the first available reserve is used.
+	HybridIndex *int `json:"hybrid_index"`
+	// TargetIndex is the enemy player (index in the game's players) an
+	// attack or ability is aimed at; when omitted the first enemy in play
+	// is targeted.
+	TargetIndex *int `json:"target_index"`
 }
 
 // SubmitAction stores a player's chosen action for the current round.
@@ -64,7 +72,13 @@ func (h *GameHandler) SubmitAction(c *gin.Context) {
 
 	// Delegate to service layer using session email as identity
 	actionType := game.PendingActionType(req.ActionType)
-	g2, resolved, err := service.SubmitAction(h.repo, g.ID, emailStr, actionType, req.EntityID, h.actionTimeout)
+	var g2 *game.Game
+	var resolved bool
+	if actionType == game.PendingActionSwitch {
+		g2, resolved, err = service.SubmitSwitch(h.repo, h.bus, g.ID, emailStr, req.HybridIndex, h.actionTimeout)
+	} else {
+		g2, resolved, err = service.SubmitTargetedAction(h.repo, h.bus, g.ID, emailStr, actionType, req.EntityID, req.TargetIndex, h.actionTimeout)
+	}
 	if err != nil {
 		switch err {
 		case service.ErrGameNotFound:
@@ -82,6 +96,21 @@ func (h *GameHandler) SubmitAction(c *gin.Context) {
 		case service.ErrNoActiveHybrid:
 			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrNoActiveHybrid})
 			return
+		case service.ErrInvalidSwitch:
+			c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidSwitch})
+			return
+		case service.ErrInvalidTarget:
+			c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidTarget})
+			return
+		case service.ErrHybridStunned:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrHybridStunned})
+			return
+		case service.ErrNotEnoughEnergy:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrNotEnoughEnergy, constants.JSONKeyCode: engine.AbilityNotEnoughEnergy})
+			return
+		case service.ErrAbilityOnCooldown:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrAbilityOnCooldown, constants.JSONKeyCode: engine.AbilityOnCooldown})
+			return
 		case service.ErrHybridHasNoSelectedAbility, service.ErrAbilityMismatch:
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -91,9 +120,62 @@ func (h *GameHandler) SubmitAction(c *gin.Context) {
 		}
 	}
 
+	// Bots that still have to act (e.g. after a server restart) are played
+	// by bot.Runner on its own goroutine; the response does not wait for
+	// their search.
 	if resolved {
 		c.JSON(http.StatusOK, gin.H{"message": "Round resolved", "round": g2.RoundCount})
 	} else {
-		c.JSON(http.StatusOK, gin.H{"message": "Action stored. Waiting for opponent."})
+		c.JSON(http.StatusOK, gin.H{"message": "Action stored. Waiting for the other players."})
+	}
+}
+
+// NextReserveRequest selects the reserve that enters when the active
+// hybrid falls; a null hybrid_index restores the default order.
+type NextReserveRequest struct {
+	HybridIndex *int `json:"hybrid_index"`
+}
+
+// SetNextReserve stores the calling player's choice of the next reserve.
+func (h *GameHandler) SetNextReserve(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
+	}
+	g, err := h.repo.FindGameByJoinCode(code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	var req NextReserveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidRequest})
+		return
+	}
+	userEmail, _ := c.Get("userEmail")
+	emailStr, _ := userEmail.(string)
+	if emailStr == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{constants.JSONKeyError: constants.ErrAuthRequired})
+		return
+	}
+
+	if _, err := service.SetNextReserve(h.repo, g.ID, emailStr, req.HybridIndex); err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		case service.ErrGameNotInProgress:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrGameNotInProgress})
+		case service.ErrActionsLocked:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrActionsLockedResolvingRound})
+		case service.ErrPlayerNotInGame:
+			c.JSON(http.StatusForbidden, gin.H{constants.JSONKeyError: constants.ErrPlayerNotInThisGame})
+		case service.ErrInvalidReserve:
+			c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidReserve})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedStoreAction})
+		}
+		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": "Next reserve stored"})
 }
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ericogr/chimera-cards/internal/ai"
+	"github.com/ericogr/chimera-cards/internal/bot"
+	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/logging"
+	"github.com/ericogr/chimera-cards/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddBotRequest selects the difficulty of the bot to add ("easy" when
+// omitted).
+type AddBotRequest struct {
+	Difficulty bot.Difficulty `json:"difficulty"`
+}
+
+// AddBot seats a server-controlled player in a game waiting for players.
+// Only participants of the game may add bots.
+func (h *GameHandler) AddBot(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
+	}
+	g, err := h.repo.FindGameByJoinCode(code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	var req AddBotRequest
+	_ = c.ShouldBindJSON(&req) // optional body
+	if req.Difficulty == "" {
+		req.Difficulty = bot.Easy
+	}
+	userEmail, _ := c.Get("userEmail")
+	emailStr, _ := userEmail.(string)
+	if emailStr == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{constants.JSONKeyError: constants.ErrAuthRequired})
+		return
+	}
+	found := false
+	for i := range g.Players {
+		if g.Players[i].PlayerEmail == emailStr {
+			found = true
+			break
+		}
+	}
+	if !found {
+		c.JSON(http.StatusForbidden, gin.H{constants.JSONKeyError: constants.ErrPlayerNotInThisGame})
+		return
+	}
+
+	if _, err := bot.Join(h.repo, h.bus, g.ID, req.Difficulty); err != nil {
+		switch err {
+		case bot.ErrInvalidDifficulty:
+			c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidBotDifficulty})
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		case bot.ErrGameNotWaiting:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrBotsOnlyBeforeStart})
+		case bot.ErrGameFull:
+			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrGameFull})
+		default:
+			logging.Error("failed to add bot", err, logging.Fields{constants.LogFieldGameID: g.ID})
+			c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedAddBot})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Bot added"})
+}
+
+// GetHint suggests the calling player's best action for the current
+// round using the search AI. Hints are limited to practice games (games
+// with at least one bot) so they cannot be used against other humans.
+func (h *GameHandler) GetHint(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
+	}
+	short, err := h.repo.FindGameByJoinCode(code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	g, err := h.repo.GetGameByID(short.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	userEmail, _ := c.Get("userEmail")
+	emailStr, _ := userEmail.(string)
+	if emailStr == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{constants.JSONKeyError: constants.ErrAuthRequired})
+		return
+	}
+	idx := -1
+	for i := range g.Players {
+		if g.Players[i].PlayerEmail == emailStr {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		c.JSON(http.StatusForbidden, gin.H{constants.JSONKeyError: constants.ErrPlayerNotInThisGame})
+		return
+	}
+	if !g.HasBots() {
+		c.JSON(http.StatusForbidden, gin.H{constants.JSONKeyError: constants.ErrHintsOnlyInPractice})
+		return
+	}
+	entities, err := h.repo.GetEntities()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchEntities})
+		return
+	}
+	s, err := ai.BestAction(g, idx, ai.Options{Entities: entities})
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrNoHintAvailable})
+		return
+	}
+	c.JSON(http.StatusOK, s)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/logging"
+	"github.com/ericogr/chimera-cards/internal/service"
+	"github.com/ericogr/chimera-cards/internal/view"
+	"github.com/gin-gonic/gin"
+)
+
+// eventsHeartbeat is how often an idle stream sends a comment so proxies
+// do not close the connection.
+const eventsHeartbeat = 25 * time.Second
+
+// StreamGameEvents streams the changes of a game as server-sent events.
+// The stream starts with a "game_updated" event holding the current state;
+// each following event is named after the change (see service.UpdateKind)
+// and carries the new state of the game, projected for the caller like
+// GetGame. The stream ends when the client disconnects.
+func (h *GameHandler) StreamGameEvents(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
+	}
+	short, err := h.repo.FindGameByJoinCode(code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	// Subscribe before loading the current state so no change saved in
+	// between is missed.
+	updates, stop := h.updates.Subscribe(short.ID)
+	defer stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	first := service.Update{Kind: service.UpdateGameChanged, GameID: short.ID, Status: short.Status, Round: short.RoundCount}
+	if !h.sendGameEvent(c, first) {
+		return
+	}
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case u, ok := <-updates:
+			return ok && h.sendGameEvent(c, u)
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// sendGameEvent writes u and the game's current state to the stream. It
+// returns false when the stream should end.
+func (h *GameHandler) sendGameEvent(c *gin.Context, u service.Update) bool {
+	g, err := h.repo.GetGameByID(u.GameID)
+	if err != nil {
+		logging.Error("failed to load game for event stream", err, logging.Fields{constants.LogFieldGameID: u.GameID})
+		return false
+	}
+	out, err := MarshalForContext(c, view.ForEmail(g, sessionEmail(c)))
+	if err != nil {
+		logging.Error("failed to encode game for event stream", err, logging.Fields{constants.LogFieldGameID: u.GameID})
+		return false
+	}
+	c.SSEvent(string(u.Kind), gin.H{
+		"type":         u.Kind,
+		"status":       u.Status,
+		"round":        u.Round,
+		"player_index": u.PlayerIndex,
+		"game":         out,
+	})
+	c.Writer.Flush()
+	return true
+}
@@ -4,6 +4,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/service"
 	"github.com/ericogr/chimera-cards/internal/storage"
 	"github.com/gin-gonic/gin"
 )
@@ -13,20 +16,26 @@ type GameHandler struct {
 	repo           storage.Repository
 	actionTimeout  time.Duration
 	publicGamesTTL time.Duration
+	updates        *service.Publisher
+	bus            *events.Bus
 }
 
-// NewGameHandler creates a new GameHandler with the given repository and
-// configured per-round action timeout and public games TTL.
-func NewGameHandler(repo storage.Repository, actionTimeout, publicGamesTTL time.Duration) *GameHandler {
-	return &GameHandler{repo: repo, actionTimeout: actionTimeout, publicGamesTTL: publicGamesTTL}
+// NewGameHandler creates a new GameHandler with the given repository,
+// configured per-round action timeout and public games TTL, the publisher
+// of the updates streamed to clients and the bus the domain events are
+// published on.
+func NewGameHandler(repo storage.Repository, actionTimeout, publicGamesTTL time.Duration, updates *service.Publisher, bus *events.Bus) *GameHandler {
+	return &GameHandler{repo: repo, actionTimeout: actionTimeout, publicGamesTTL: publicGamesTTL, updates: updates, bus: bus}
 }
 
 // GetConfig returns runtime configuration values consumed by the frontend.
 // It exposes the public games TTL and the per-round action timeout as
-// integer seconds to simplify client parsing.
+// integer seconds to simplify client parsing, plus the combat ruleset in
+// effect.
 func (h *GameHandler) GetConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"public_games_ttl_seconds": int(h.publicGamesTTL.Seconds()),
 		"action_timeout_seconds":   int(h.actionTimeout.Seconds()),
+		"rules":                    engine.CurrentRules(),
 	})
 }
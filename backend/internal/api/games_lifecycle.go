@@ -2,10 +2,10 @@ package api
 
 import (
 	"net/http"
-	"time"
 	"unicode/utf8"
 
 	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/logging"
 	"github.com/ericogr/chimera-cards/internal/service"
@@ -19,6 +19,10 @@ type CreateGamePayload struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Private     bool   `json:"private"`
+	// HybridCount is the number of hybrids per player (1–5, default 2).
+	HybridCount int `json:"hybrid_count"`
+	// Mode is "duel" (default) or "teams" for 2v2 matches.
+	Mode game.GameMode `json:"mode"`
 }
 
 // CreateGame creates a new game and returns IDs and join code.
@@ -47,17 +51,33 @@ func (h *GameHandler) CreateGame(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrDescriptionExceeds})
 		return
 	}
+	if req.HybridCount == 0 {
+		req.HybridCount = game.DefaultHybridCount
+	}
+	if req.HybridCount < game.MinHybridCount || req.HybridCount > game.MaxHybridCount {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidHybridCountSetting})
+		return
+	}
+	if !req.Mode.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameMode})
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = game.ModeDuel
+	}
 
 	newGame := game.Game{
 		Name:        req.Name,
 		Description: req.Description,
 		Private:     req.Private,
+		HybridCount: req.HybridCount,
+		Mode:        req.Mode,
 		Status:      game.StatusWaitingForPlayers,
 		JoinCode:    joinCode,
 		Players: []game.Player{
 			{PlayerName: req.PlayerName, PlayerEmail: req.PlayerEmail},
 		},
-		Message: "Game created. Waiting for second player.",
+		Message: "Game created. Waiting for other players.",
 	}
 
 	// Upsert user profile (name/email)
@@ -67,6 +87,7 @@ func (h *GameHandler) CreateGame(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedCreateGame})
 		return
 	}
+	h.bus.Publish(events.NewGameCreated(newGame.Clone()))
 
 	c.JSON(http.StatusCreated, gin.H{
 		"game_id":   newGame.ID,
@@ -80,7 +101,8 @@ type JoinGamePayload struct {
 	PlayerEmail string `json:"player_email"`
 }
 
-// JoinGame allows a second player to join a game via join code.
+// JoinGame allows another player to join a game via join code. In team
+// games players are seated on alternating teams.
 func (h *GameHandler) JoinGame(c *gin.Context) {
 	var req JoinGamePayload
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -105,16 +127,23 @@ func (h *GameHandler) JoinGame(c *gin.Context) {
 		return
 	}
 
-	if len(g.Players) >= 2 {
+	if len(g.Players) >= g.MaxPlayers() {
 		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrGameFull})
 		return
 	}
 
-	newPlayer := game.Player{PlayerName: req.PlayerName, PlayerEmail: req.PlayerEmail}
+	newPlayer := game.Player{PlayerName: req.PlayerName, PlayerEmail: req.PlayerEmail, Team: g.NextTeam()}
 
 	g.Players = append(g.Players, newPlayer)
 	g.Status = game.StatusWaitingForPlayers
-	g.Message = "Second player joined. Waiting for the game to start."
+	if len(g.Players) < g.MaxPlayers() {
+		g.Message = "A player joined. Waiting for other players."
+	} else {
+		g.Message = "Second player joined. Waiting for the game to start."
+		if g.MaxPlayers() > 2 {
+			g.Message = "All players joined. Waiting for the game to start."
+		}
+	}
 
 	// Upsert user profile (name/email)
 	_ = h.repo.UpsertUser(req.PlayerEmail, req.PlayerName)
@@ -123,6 +152,7 @@ func (h *GameHandler) JoinGame(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedUpdateGame})
 		return
 	}
+	h.bus.Publish(events.NewPlayerJoined(g.Clone(), len(g.Players)-1))
 
 	c.JSON(http.StatusOK, gin.H{
 		"game_id":   g.ID,
@@ -150,13 +180,13 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 		return
 	}
 
-	if len(g.Players) < 2 {
+	if len(g.Players) < g.MaxPlayers() {
 		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrNotEnoughPlayers})
 		return
 	}
 
-	// Ensure both players created hybrids
-	if len(g.Players) != 2 || !g.Players[0].HasCreated || !g.Players[1].HasCreated {
+	// Ensure every player created hybrids
+	if !g.AllCreated() {
 		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrBothPlayersMustCreateHybrids})
 		return
 	}
@@ -175,6 +205,7 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedUpdateGameStatus})
 		return
 	}
+	h.bus.Publish(events.NewGameStarting(g.Clone()))
 
 	// Run the heavy work asynchronously so the request returns immediately
 	// and both players can see the "starting" message while generation runs.
@@ -186,18 +217,17 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 			logging.Error("async-start failed to load game", err, logging.Fields{constants.LogFieldGameID: gameID})
 			return
 		}
-		if err := service.StartGame(h.repo, gg); err != nil {
+		if err := service.StartGame(h.repo, h.bus, gg, h.actionTimeout); err != nil {
 			logging.Error("async-start failed to start game", err, logging.Fields{constants.LogFieldGameID: gameID})
 			// Update game to a visible error state so players aren't left
 			// waiting forever.
 			gg.Status = game.StatusError
 			gg.Message = "Failed to create hybrid names or images. Please try again."
-			_ = h.repo.UpdateGame(gg)
+			if err := h.repo.UpdateGame(gg); err == nil {
+				h.bus.Publish(events.NewGameStartFailed(gg.Clone()))
+			}
 			return
 		}
-		// Set initial action deadline for the first planning phase.
-		gg.ActionDeadline = time.Now().Add(h.actionTimeout)
-		_ = h.repo.UpdateGame(gg)
 	}(g.ID)
 
 	c.JSON(http.StatusAccepted, gin.H{"message": "Game starting"})
@@ -245,9 +275,12 @@ func (h *GameHandler) LeaveGame(c *gin.Context) {
 	}
 	// Reflect removal in the in-memory model to avoid re-attaching via FullSaveAssociations
 	filtered := make([]game.Player, 0, len(g.Players))
-	for _, p := range g.Players {
+	leftIdx := -1
+	for i, p := range g.Players {
 		if (p.PlayerEmail) != emailStr {
 			filtered = append(filtered, p)
+		} else {
+			leftIdx = i
 		}
 	}
 	g.Players = filtered
@@ -258,6 +291,7 @@ func (h *GameHandler) LeaveGame(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrPlayerRemovedFailedUpdate})
 		return
 	}
+	h.bus.Publish(events.NewPlayerLeft(g.Clone(), leftIdx))
 	c.JSON(http.StatusOK, gin.H{"message": "Player removed"})
 }
 
@@ -294,9 +328,11 @@ func (h *GameHandler) EndGame(c *gin.Context) {
 	}
 
 	var loser *game.Player
+	loserIdx := -1
 	for i := range g.Players {
 		if g.Players[i].PlayerEmail == emailStr {
 			loser = &g.Players[i]
+			loserIdx = i
 			break
 		}
 	}
@@ -313,7 +349,8 @@ func (h *GameHandler) EndGame(c *gin.Context) {
 	}
 
 	// Update stats on resignation if not already counted
-	if !g.StatsCounted {
+	finished := !g.StatsCounted
+	if finished {
 		resignedEmail := loser.PlayerEmail
 		_ = h.repo.UpdateStatsOnGameEnd(g, resignedEmail)
 		g.StatsCounted = true
@@ -322,5 +359,9 @@ func (h *GameHandler) EndGame(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedEndGame})
 		return
 	}
+	if finished {
+		h.bus.Publish(events.NewPlayerResigned(g.Clone(), loserIdx))
+		h.bus.Publish(events.NewGameFinished(g.Clone(), events.FinishResigned, loser.PlayerEmail))
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Game ended"})
 }
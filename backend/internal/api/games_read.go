@@ -1,20 +1,27 @@
 package api
 
 import (
+	"bytes"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/ericogr/chimera-cards/internal/constants"
 	"github.com/ericogr/chimera-cards/internal/engine"
 	"github.com/ericogr/chimera-cards/internal/game"
-	"github.com/ericogr/chimera-cards/internal/logging"
-	"github.com/ericogr/chimera-cards/internal/service"
+	"github.com/ericogr/chimera-cards/internal/replay"
+	"github.com/ericogr/chimera-cards/internal/view"
 	"github.com/gin-gonic/gin"
 )
 
+// entityListing is an entity as returned by ListEntities: the entity plus
+// the synergies it can contribute to.
+type entityListing struct {
+	game.Entity
+	Synergies []game.Synergy `json:"synergies,omitempty"`
+}
+
 // ListEntities returns all available entities.
 func (h *GameHandler) ListEntities(c *gin.Context) {
 	entities, err := h.repo.GetEntities()
@@ -22,7 +29,16 @@ func (h *GameHandler) ListEntities(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchEntities})
 		return
 	}
-	c.JSON(http.StatusOK, entities)
+	out := make([]entityListing, len(entities))
+	for i := range entities {
+		out[i].Entity = entities[i]
+		for _, s := range engine.CurrentSynergies() {
+			if s.Involves(entities[i]) {
+				out[i].Synergies = append(out[i].Synergies, s)
+			}
+		}
+	}
+	c.JSON(http.StatusOK, out)
 }
 
 // ListPublicGames returns all public games waiting for players or in progress.
@@ -32,7 +48,7 @@ func (h *GameHandler) ListPublicGames(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchGames})
 		return
 	}
-	out, err := MarshalForContext(c, games)
+	out, err := MarshalForContext(c, view.ForEmailAll(games, sessionEmail(c)))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedEncodeGames})
 		return
@@ -82,104 +98,83 @@ func (h *GameHandler) GetGame(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
 		return
 	}
-	// Log basic game state for debugging timeouts
-	if len(g.Players) >= 2 {
-		logging.Info("GET /api/games state", logging.Fields{
-			constants.LogFieldGameID: g.ID,
-			"phase":                  g.Phase,
-			"action_deadline":        g.ActionDeadline,
-			"p1_submitted":           g.Players[0].HasSubmittedAction,
-			"p2_submitted":           g.Players[1].HasSubmittedAction,
-		})
-	} else {
-		logging.Info("GET /api/games state (incomplete players)", logging.Fields{constants.LogFieldGameID: g.ID, "phase": g.Phase, "action_deadline": g.ActionDeadline})
+	// Only send what the caller may know: the opponents' pending actions
+	// and hidden reserves are projected out.
+	out, err := MarshalForContext(c, view.ForEmail(g, sessionEmail(c)))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedEncodeGame})
+		return
 	}
+	c.JSON(http.StatusOK, out)
+}
 
-	// If the planning deadline has passed, attempt an immediate best-effort
-	// resolution: if exactly one player is missing, auto-submit `rest` for
-	// them so the round resolves immediately. If both players missed, end
-	// the match as before. This helps clients that refresh the page right
-	// after the deadline and avoids waiting for the background scanner.
-	if g.Phase == game.PhasePlanning && !g.ActionDeadline.IsZero() && g.ActionDeadline.Before(time.Now()) {
-		if len(g.Players) >= 2 {
-			p1Submitted := g.Players[0].HasSubmittedAction
-			p2Submitted := g.Players[1].HasSubmittedAction
-			switch {
-			case !p1Submitted && !p2Submitted:
-				// both missed -> finish match immediately
-				g.Status = game.StatusFinished
-				g.Phase = game.PhaseResolved
-				g.Winner = ""
-				g.Message = "Match ended due to inactivity"
-				g.LastRoundSummary = "Round timed out: both players failed to submit actions within the allotted time."
-				g.StatsCounted = true
-				g.ActionDeadline = time.Time{}
-				if err := h.repo.UpdateGame(g); err != nil {
-					logging.Error("failed to expire game (GET handler)", err, logging.Fields{constants.LogFieldGameID: g.ID})
-				}
-			case p1Submitted && !p2Submitted:
-				// auto-submit REST for player 2
-				if gr, ok := h.repo.(service.GameRepo); ok {
-					_, _, serr := service.SubmitAction(gr, g.ID, g.Players[1].PlayerEmail, game.PendingActionRest, 0, h.actionTimeout)
-					if serr != nil {
-						logging.Error("GET handler failed to auto-submit rest", serr, logging.Fields{constants.LogFieldGameID: g.ID})
-					}
-					// reload
-					if gg, err := h.repo.GetGameByID(short.ID); err == nil {
-						g = gg
-					}
-				} else {
-					// fallback inline
-					g.Players[1].HasSubmittedAction = true
-					g.Players[1].PendingActionType = game.PendingActionRest
-					g.Players[1].PendingActionEntityID = nil
-					engine.ResolveRound(g)
-					if g.Status == game.StatusFinished {
-						if !g.StatsCounted {
-							_ = h.repo.UpdateStatsOnGameEnd(g, "")
-							g.StatsCounted = true
-						}
-					} else {
-						g.ActionDeadline = time.Now().Add(h.actionTimeout)
-					}
-					_ = h.repo.UpdateGame(g)
-				}
-			case !p1Submitted && p2Submitted:
-				// auto-submit REST for player 1 (symmetric)
-				if gr, ok := h.repo.(service.GameRepo); ok {
-					_, _, serr := service.SubmitAction(gr, g.ID, g.Players[0].PlayerEmail, game.PendingActionRest, 0, h.actionTimeout)
-					if serr != nil {
-						logging.Error("GET handler failed to auto-submit rest", serr, logging.Fields{constants.LogFieldGameID: g.ID})
-					}
-					if gg, err := h.repo.GetGameByID(short.ID); err == nil {
-						g = gg
-					}
-				} else {
-					g.Players[0].HasSubmittedAction = true
-					g.Players[0].PendingActionType = game.PendingActionRest
-					g.Players[0].PendingActionEntityID = nil
-					engine.ResolveRound(g)
-					if g.Status == game.StatusFinished {
-						if !g.StatsCounted {
-							_ = h.repo.UpdateStatsOnGameEnd(g, "")
-							g.StatsCounted = true
-						}
-					} else {
-						g.ActionDeadline = time.Now().Add(h.actionTimeout)
-					}
-					_ = h.repo.UpdateGame(g)
-				}
-			}
-		}
+// GetGameRounds returns the round-by-round history of a finished match:
+// the actions both players chose, hybrid state before and after each
+// round, and the round's events and summary.
+func (h *GameHandler) GetGameRounds(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
 	}
-	out, err := MarshalForContext(c, g)
+	g, err := h.repo.FindGameByJoinCode(code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedEncodeGame})
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	if g.Status != game.StatusFinished {
+		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrGameNotFinished})
+		return
+	}
+	rounds, err := h.repo.GetGameRounds(g.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchRounds})
+		return
+	}
+	out, err := MarshalForContext(c, rounds)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchRounds})
 		return
 	}
 	c.JSON(http.StatusOK, out)
 }
 
+// ExportGameReplay downloads a finished match as a replay file that can be
+// re-simulated offline with `chimera-cards replay`.
+func (h *GameHandler) ExportGameReplay(c *gin.Context) {
+	code := normalizeJoinCode(c.Param("gameCode"))
+	if code == "" || !joinCodeRegex.MatchString(code) {
+		c.JSON(http.StatusBadRequest, gin.H{constants.JSONKeyError: constants.ErrInvalidGameID})
+		return
+	}
+	short, err := h.repo.FindGameByJoinCode(code)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	if short.Status != game.StatusFinished {
+		c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrGameNotFinished})
+		return
+	}
+	g, err := h.repo.GetGameByID(short.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
+		return
+	}
+	rounds, err := h.repo.GetGameRounds(g.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedFetchRounds})
+		return
+	}
+	var buf bytes.Buffer
+	if err := replay.Encode(&buf, replay.Build(g, rounds)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{constants.JSONKeyError: constants.ErrFailedExportReplay})
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=\"chimera-"+g.JoinCode+".replay.json\"")
+	c.Data(http.StatusOK, "application/json", buf.Bytes())
+}
+
 // GetPlayerStats returns aggregated stats for a given player UUID.
 func (h *GameHandler) GetPlayerStats(c *gin.Context) {
 	email := c.Query("email")
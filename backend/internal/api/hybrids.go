@@ -18,8 +18,12 @@ type CreateHybridSpec struct {
 }
 
 type CreateHybridsPayload struct {
-	Hybrid1 CreateHybridSpec `json:"hybrid1"`
-	Hybrid2 CreateHybridSpec `json:"hybrid2"`
+	// Hybrids lists one spec per hybrid of the player's team.
+	Hybrids []CreateHybridSpec `json:"hybrids"`
+	// Hybrid1 and Hybrid2 are the legacy two-hybrid form, used when
+	// Hybrids is empty.
+	Hybrid1 *CreateHybridSpec `json:"hybrid1,omitempty"`
+	Hybrid2 *CreateHybridSpec `json:"hybrid2,omitempty"`
 }
 
 func sumEntityStats(entities []game.Entity) (hitPoints, attack, defense, agility, energy int) {
@@ -36,7 +40,7 @@ func sumEntityStats(entities []game.Entity) (hitPoints, attack, defense, agility
 // Vigor cost per entity is configurable via the chimera_config.json and
 // stored on each Entity as `VigorCost`.
 
-// CreateHybrids stores two hybrids for a player in a game.
+// CreateHybrids stores a player's team of hybrids in a game.
 func (h *GameHandler) CreateHybrids(c *gin.Context) {
 	// The path param is the game's join code
 	code := normalizeJoinCode(c.Param("gameCode"))
@@ -75,13 +79,20 @@ func (h *GameHandler) CreateHybrids(c *gin.Context) {
 		return
 	}
 
-	srvReq := service.CreateHybridsRequest{
-		PlayerEmail: emailStr,
-		Hybrid1:     service.CreateHybridSpec{EntityIDs: req.Hybrid1.EntityIDs, SelectedEntityID: req.Hybrid1.SelectedEntityID},
-		Hybrid2:     service.CreateHybridSpec{EntityIDs: req.Hybrid2.EntityIDs, SelectedEntityID: req.Hybrid2.SelectedEntityID},
+	specs := req.Hybrids
+	if len(specs) == 0 {
+		for _, s := range []*CreateHybridSpec{req.Hybrid1, req.Hybrid2} {
+			if s != nil {
+				specs = append(specs, *s)
+			}
+		}
+	}
+	srvReq := service.CreateHybridsRequest{PlayerEmail: emailStr}
+	for _, s := range specs {
+		srvReq.Hybrids = append(srvReq.Hybrids, service.CreateHybridSpec{EntityIDs: s.EntityIDs, SelectedEntityID: s.SelectedEntityID})
 	}
 
-	if err := service.CreateHybrids(h.repo, g.ID, srvReq); err != nil {
+	if err := service.CreateHybrids(h.repo, h.bus, g.ID, srvReq); err != nil {
 		switch err {
 		case service.ErrGameNotFound:
 			c.JSON(http.StatusNotFound, gin.H{constants.JSONKeyError: constants.ErrGameNotFound})
@@ -92,7 +103,7 @@ func (h *GameHandler) CreateHybrids(c *gin.Context) {
 		case service.ErrHybridsAlreadyCreated:
 			c.JSON(http.StatusConflict, gin.H{constants.JSONKeyError: constants.ErrHybridsAlreadyCreated})
 			return
-		case service.ErrInvalidHybridCount, service.ErrInvalidSelectedAbility, service.ErrEntityReused, service.ErrInvalidEntities:
+		case service.ErrWrongTeamSize, service.ErrInvalidHybridCount, service.ErrInvalidSelectedAbility, service.ErrEntityReused, service.ErrInvalidEntities:
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		default:
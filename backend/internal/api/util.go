@@ -84,18 +84,21 @@ func MarshalForContext(c *gin.Context, v interface{}) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	currentEmail := ""
-	if c != nil {
-		if v, ok := c.Get("userEmail"); ok {
-			if s, _ := v.(string); s != "" {
-				currentEmail = s
-			}
-		}
-	}
-	redactEmails(out, currentEmail)
+	redactEmails(out, sessionEmail(c))
 	return out, nil
 }
 
+// sessionEmail returns the email of the authenticated session user, or ""
+// when there is none.
+func sessionEmail(c *gin.Context) string {
+	if c == nil {
+		return ""
+	}
+	v, _ := c.Get("userEmail")
+	s, _ := v.(string)
+	return s
+}
+
 // redactEmails walks a marshalled JSON structure (decoded into
 // map[string]interface{} / []interface{}) and removes any field whose
 // key contains "email" (case-insensitive) unless its value equals
@@ -0,0 +1,122 @@
+// Package balance measures how the configured entities, skills and
+// hybrids fare against each other. It plays many one-on-one matches
+// between hybrid builds with simple built-in action policies, resolving
+// rounds with the engine, and aggregates the results into win-rate
+// matrices.
+package balance
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Options configures a simulation; zero values pick the defaults.
+type Options struct {
+	// Matches is the number of matches played.
+	Matches int
+	// Builds samples that many hybrid builds instead of using all of them.
+	Builds int
+	// Policies are the action policies matches are played with; both
+	// players of a match follow the same one and matches cycle through
+	// the list.
+	Policies []string
+	// Seed makes the run reproducible: the same seed and options give the
+	// same report whatever the number of workers.
+	Seed int64
+	// Workers is the number of matches played in parallel.
+	Workers int
+	// MaxRounds ends matches that last longer without a winner.
+	MaxRounds int
+}
+
+// Defaults used when Options leaves them unset.
+const (
+	DefaultMatches   = 10000
+	DefaultMaxRounds = 100
+)
+
+// ErrTooFewBuilds is returned when fewer than two builds can be made.
+var ErrTooFewBuilds = errors.New("at least two hybrid builds are needed")
+
+// result is the outcome of one match between builds a and b; winner is
+// 0 when a won, 1 when b won and -1 when nobody did.
+type result struct {
+	a, b, winner int
+}
+
+// Run plays the matches and returns the report. Each match pits two
+// distinct builds picked at random.
+func Run(entities []game.Entity, synergies []game.Synergy, rules game.Rules, o Options) (*Report, error) {
+	if o.Matches <= 0 {
+		o.Matches = DefaultMatches
+	}
+	if o.MaxRounds <= 0 {
+		o.MaxRounds = DefaultMaxRounds
+	}
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	if len(o.Policies) == 0 {
+		o.Policies = DefaultPolicies
+	}
+	if _, err := ParsePolicies(strings.Join(o.Policies, ",")); err != nil {
+		return nil, err
+	}
+	// Entities loaded from the configuration carry no database IDs; number
+	// them so hybrids can tell their abilities apart.
+	entities = append([]game.Entity(nil), entities...)
+	for i := range entities {
+		entities[i].ID = uint(i + 1)
+	}
+	builds := SampleBuilds(EnumerateBuilds(entities), o.Builds, rand.New(rand.NewSource(o.Seed)))
+	if len(builds) < 2 {
+		return nil, ErrTooFewBuilds
+	}
+	hybrids := make([]game.Hybrid, len(builds))
+	for i, b := range builds {
+		hybrids[i] = b.Hybrid(synergies)
+	}
+
+	jobs := make(chan int)
+	results := make(chan result)
+	var wg sync.WaitGroup
+	for w := 0; w < o.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for m := range jobs {
+				// Every match draws from its own source so the outcome
+				// does not depend on which worker plays it.
+				seed := o.Seed + int64(m)*7919 + 1
+				rng := rand.New(rand.NewSource(seed))
+				a := rng.Intn(len(builds))
+				b := rng.Intn(len(builds) - 1)
+				if b >= a {
+					b++
+				}
+				policy := policies[o.Policies[m%len(o.Policies)]]
+				winner := playMatch(hybrids[a], hybrids[b], policy, rules, seed, o.MaxRounds, rng)
+				results <- result{a: a, b: b, winner: winner}
+			}
+		}()
+	}
+	go func() {
+		for m := 0; m < o.Matches; m++ {
+			jobs <- m
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	rep := newReport(builds, o)
+	for r := range results {
+		rep.add(builds, r)
+	}
+	rep.finish()
+	return rep, nil
+}
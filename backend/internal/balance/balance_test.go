@@ -0,0 +1,66 @@
+package balance
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func testEntities() []game.Entity {
+	mk := func(name string, hp, atk, def, agi int) game.Entity {
+		return game.Entity{Name: name, HitPoints: hp, Attack: atk, Defense: def, Agility: agi, Energy: 2,
+			Skill: game.Skill{Name: name + " Skill", Cost: 2, Effect: game.SkillEffect{Heal: 3}}}
+	}
+	return []game.Entity{mk("A", 10, 4, 2, 5), mk("B", 8, 5, 1, 7), mk("C", 12, 3, 3, 3), mk("D", 9, 4, 2, 4)}
+}
+
+func TestEnumerateBuilds(t *testing.T) {
+	// C(4,2)*2 + C(4,3)*3 builds.
+	if n := len(EnumerateBuilds(testEntities())); n != 24 {
+		t.Fatalf("expected 24 builds, got %d", n)
+	}
+}
+
+func TestRun_CountsAndDeterminism(t *testing.T) {
+	o := Options{Matches: 300, Seed: 5, Workers: 1, MaxRounds: 50}
+	r1, err := Run(testEntities(), nil, game.DefaultRules(), o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r1.Matches != 300 || r1.Builds != 24 {
+		t.Fatalf("expected 300 matches between 24 builds, got %d and %d", r1.Matches, r1.Builds)
+	}
+	games, wins := 0, 0
+	for _, row := range r1.Hybrids.Overall {
+		games += row.Games
+		wins += row.Wins
+	}
+	if games != 2*r1.Matches || wins != r1.Matches-r1.Draws {
+		t.Fatalf("hybrid totals do not add up: %d games, %d wins, %d draws", games, wins, r1.Draws)
+	}
+	for i, row := range r1.Entities.WinRate {
+		if row[i] != nil {
+			t.Fatalf("entity %s has a win rate against itself", r1.Entities.Labels[i])
+		}
+	}
+
+	o.Workers = 4
+	r4, err := Run(testEntities(), nil, game.DefaultRules(), o)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var b1, b4 bytes.Buffer
+	_ = r1.WriteJSON(&b1)
+	_ = r4.WriteJSON(&b4)
+	if !reflect.DeepEqual(b1.Bytes(), b4.Bytes()) {
+		t.Fatalf("the report depends on the number of workers")
+	}
+}
+
+func TestRun_RejectsUnknownPolicy(t *testing.T) {
+	if _, err := Run(testEntities(), nil, game.DefaultRules(), Options{Matches: 1, Policies: []string{"cautious"}}); err == nil {
+		t.Fatalf("expected an error for an unknown policy")
+	}
+}
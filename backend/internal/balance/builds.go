@@ -0,0 +1,70 @@
+package balance
+
+import (
+	"math/rand"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Build is one way to create a hybrid: 2–3 distinct entities and the
+// entity whose skill is the hybrid's primary ability.
+type Build struct {
+	Entities []game.Entity
+	Selected game.Entity
+}
+
+// Name identifies the build in reports, e.g. "Lion + Raven [Commanding Roar]".
+func (b Build) Name() string {
+	return engine.CombinedName(b.Entities) + " [" + skillName(b.Selected) + "]"
+}
+
+// Hybrid builds the hybrid matching the given synergies.
+func (b Build) Hybrid(synergies []game.Synergy) game.Hybrid {
+	return engine.BuildHybridWithSynergies(b.Entities, b.Selected.ID, synergies)
+}
+
+// EnumerateBuilds returns every build that can be made from entities:
+// each combination of two or three of them with each choice of primary
+// ability.
+func EnumerateBuilds(entities []game.Entity) []Build {
+	var out []Build
+	var walk func(start int, cur []game.Entity)
+	walk = func(start int, cur []game.Entity) {
+		if len(cur) >= 2 {
+			for _, sel := range cur {
+				out = append(out, Build{Entities: append([]game.Entity(nil), cur...), Selected: sel})
+			}
+		}
+		if len(cur) == 3 {
+			return
+		}
+		for i := start; i < len(entities); i++ {
+			walk(i+1, append(cur, entities[i]))
+		}
+	}
+	walk(0, nil)
+	return out
+}
+
+// SampleBuilds returns n builds picked at random without repetition (all
+// of them, in order, when n <= 0 or n >= len(builds)).
+func SampleBuilds(builds []Build, n int, rng *rand.Rand) []Build {
+	if n <= 0 || n >= len(builds) {
+		return builds
+	}
+	out := make([]Build, n)
+	for i, j := range rng.Perm(len(builds))[:n] {
+		out[i] = builds[j]
+	}
+	return out
+}
+
+// skillName labels an entity's skill, falling back to the entity name
+// for skills without one.
+func skillName(e game.Entity) string {
+	if e.Skill.Name != "" {
+		return e.Skill.Name
+	}
+	return e.Name
+}
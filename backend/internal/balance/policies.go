@@ -0,0 +1,128 @@
+package balance
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/ericogr/chimera-cards/internal/ai"
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Policy chooses the action of the player at idx from the legal
+// candidates.
+type Policy func(g *game.Game, idx int, candidates []ai.Action, rng *rand.Rand) ai.Action
+
+// Built-in policy names.
+const (
+	PolicyRandom    = "random"
+	PolicyGreedy    = "greedy"
+	PolicyDefensive = "defensive"
+)
+
+// DefaultPolicies lists the built-in policies in the order they are
+// reported.
+var DefaultPolicies = []string{PolicyRandom, PolicyGreedy, PolicyDefensive}
+
+var policies = map[string]Policy{
+	PolicyRandom:    randomPolicy,
+	PolicyGreedy:    greedyPolicy,
+	PolicyDefensive: defensivePolicy,
+}
+
+// ParsePolicies parses a comma-separated list of policy names.
+func ParsePolicies(s string) ([]string, error) {
+	var out []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := policies[name]; !ok {
+			return nil, fmt.Errorf("unknown policy %q (known: %s)", name, strings.Join(DefaultPolicies, ", "))
+		}
+		out = append(out, name)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no policy given")
+	}
+	return out, nil
+}
+
+// randomPolicy picks any legal action.
+func randomPolicy(g *game.Game, idx int, candidates []ai.Action, rng *rand.Rand) ai.Action {
+	return candidates[rng.Intn(len(candidates))]
+}
+
+// greedyPolicy uses the primary ability whenever it is usable and the
+// hybrid has the Vigor it costs, rests when out of Vigor and attacks
+// otherwise.
+func greedyPolicy(g *game.Game, idx int, candidates []ai.Action, rng *rand.Rand) ai.Action {
+	h := engine.ActiveHybrid(&g.Players[idx])
+	if h.CurrentVIG == 0 {
+		return pick(candidates, game.PendingActionRest)
+	}
+	for _, c := range candidates {
+		if c.Type != game.PendingActionAbility || h.SelectedAbilityEntityID == nil || c.EntityID != *h.SelectedAbilityEntityID {
+			continue
+		}
+		for _, e := range h.BaseEntities {
+			if e.ID == c.EntityID && h.CurrentVIG >= e.VigorCost {
+				return c
+			}
+		}
+	}
+	return pick(candidates, game.PendingActionBasicAttack)
+}
+
+// defensivePolicy defends while hurt, rests before running out of Vigor
+// and attacks otherwise.
+func defensivePolicy(g *game.Game, idx int, candidates []ai.Action, rng *rand.Rand) ai.Action {
+	h := engine.ActiveHybrid(&g.Players[idx])
+	switch {
+	case h.CurrentVIG <= 1:
+		return pick(candidates, game.PendingActionRest)
+	case h.CurrentHitPoints*2 < h.BaseHitPoints:
+		return pick(candidates, game.PendingActionDefend)
+	}
+	return pick(candidates, game.PendingActionBasicAttack)
+}
+
+// pick returns the first candidate of type t (the first candidate when
+// there is none, e.g. a stunned hybrid).
+func pick(candidates []ai.Action, t game.PendingActionType) ai.Action {
+	for _, c := range candidates {
+		if c.Type == t {
+			return c
+		}
+	}
+	return candidates[0]
+}
+
+// playMatch plays a match between two single-hybrid players following
+// policy and returns the index of the winner (-1 when nobody won within
+// maxRounds). a and b are copied, so builds can be shared between
+// goroutines.
+func playMatch(a, b game.Hybrid, policy Policy, rules game.Rules, seed int64, maxRounds int, rng *rand.Rand) int {
+	g := (&game.Game{RNGSeed: seed, HybridCount: 1, Players: []game.Player{
+		{PlayerName: "A", HasCreated: true, Hybrids: []game.Hybrid{a}},
+		{PlayerName: "B", HasCreated: true, Hybrids: []game.Hybrid{b}},
+	}}).Clone()
+	engine.PrepareMatchWithRules(g, rules)
+	for g.Status == game.StatusInProgress && g.RoundCount <= maxRounds {
+		for i := range g.Players {
+			if g.Players[i].HasSubmittedAction {
+				continue
+			}
+			if c := ai.Candidates(g, i, rules); len(c) > 0 {
+				ai.Apply(g, i, policy(g, i, c, rng))
+			}
+		}
+		engine.ResolveRoundWithRules(g, rules)
+	}
+	if g.Status != game.StatusFinished || g.WinningTeam == nil {
+		return -1
+	}
+	return *g.WinningTeam
+}
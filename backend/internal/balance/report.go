@@ -0,0 +1,222 @@
+package balance
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Report holds the win-rate matrices of a simulation.
+type Report struct {
+	Matches   int      `json:"matches"`
+	Draws     int      `json:"draws"`
+	Builds    int      `json:"builds"`
+	Policies  []string `json:"policies"`
+	Seed      int64    `json:"seed"`
+	MaxRounds int      `json:"max_rounds"`
+	Entities  *Table   `json:"entities"`
+	Skills    *Table   `json:"skills"`
+	Hybrids   *Table   `json:"hybrids"`
+
+	// rows maps entity IDs to their row in Entities and Skills.
+	rows map[uint]int
+}
+
+// Table is a win-rate matrix: cell [i][j] is how often a hybrid with row
+// i won against a hybrid with column j. Matches without a winner count
+// as games but not as wins.
+type Table struct {
+	Labels []string `json:"labels"`
+	Games  [][]int  `json:"games"`
+	Wins   [][]int  `json:"wins"`
+	// WinRate is Wins/Games, or null for pairs that never met.
+	WinRate [][]*float64 `json:"win_rate"`
+	Overall []Overall    `json:"overall"`
+}
+
+// Overall sums a table row over every opponent.
+type Overall struct {
+	Label   string   `json:"label"`
+	Games   int      `json:"games"`
+	Wins    int      `json:"wins"`
+	WinRate *float64 `json:"win_rate"`
+}
+
+func newTable(labels []string) *Table {
+	t := &Table{Labels: labels, Games: make([][]int, len(labels)), Wins: make([][]int, len(labels))}
+	for i := range labels {
+		t.Games[i] = make([]int, len(labels))
+		t.Wins[i] = make([]int, len(labels))
+	}
+	return t
+}
+
+// record counts a game between rows in a and rows in b. Pairs with the
+// same row on both sides (a shared entity) are skipped.
+func (t *Table) record(a, b []int, winner int) {
+	for _, i := range a {
+		for _, j := range b {
+			if i == j {
+				continue
+			}
+			t.Games[i][j]++
+			t.Games[j][i]++
+			switch winner {
+			case 0:
+				t.Wins[i][j]++
+			case 1:
+				t.Wins[j][i]++
+			}
+		}
+	}
+}
+
+func (t *Table) finish() {
+	t.WinRate = make([][]*float64, len(t.Labels))
+	t.Overall = make([]Overall, len(t.Labels))
+	for i := range t.Labels {
+		t.WinRate[i] = make([]*float64, len(t.Labels))
+		o := Overall{Label: t.Labels[i]}
+		for j := range t.Labels {
+			t.WinRate[i][j] = rate(t.Wins[i][j], t.Games[i][j])
+			o.Games += t.Games[i][j]
+			o.Wins += t.Wins[i][j]
+		}
+		o.WinRate = rate(o.Wins, o.Games)
+		t.Overall[i] = o
+	}
+}
+
+func rate(wins, games int) *float64 {
+	if games == 0 {
+		return nil
+	}
+	r := float64(wins) / float64(games)
+	return &r
+}
+
+// newReport prepares the tables for builds. Entities and skills are
+// indexed like the entities the builds are made of, in order of first
+// appearance.
+func newReport(builds []Build, o Options) *Report {
+	var names, skills []string
+	rows := map[uint]int{}
+	for _, b := range builds {
+		for _, e := range b.Entities {
+			if _, ok := rows[e.ID]; !ok {
+				rows[e.ID] = len(names)
+				names = append(names, e.Name)
+				skills = append(skills, skillName(e))
+			}
+		}
+	}
+	labels := make([]string, len(builds))
+	for i, b := range builds {
+		labels[i] = b.Name()
+	}
+	return &Report{
+		Builds:    len(builds),
+		Policies:  o.Policies,
+		Seed:      o.Seed,
+		MaxRounds: o.MaxRounds,
+		Entities:  newTable(names),
+		Skills:    newTable(skills),
+		Hybrids:   newTable(labels),
+		rows:      rows,
+	}
+}
+
+func (r *Report) add(builds []Build, res result) {
+	r.Matches++
+	if res.winner < 0 {
+		r.Draws++
+	}
+	ea, eb := r.entityRows(builds[res.a]), r.entityRows(builds[res.b])
+	r.Entities.record(ea, eb, res.winner)
+	r.Skills.record([]int{r.rows[builds[res.a].Selected.ID]}, []int{r.rows[builds[res.b].Selected.ID]}, res.winner)
+	r.Hybrids.record([]int{res.a}, []int{res.b}, res.winner)
+}
+
+func (r *Report) finish() {
+	r.Entities.finish()
+	r.Skills.finish()
+	r.Hybrids.finish()
+}
+
+func (r *Report) entityRows(b Build) []int {
+	rows := make([]int, len(b.Entities))
+	for i, e := range b.Entities {
+		rows[i] = r.rows[e.ID]
+	}
+	return rows
+}
+
+// WriteJSON writes the whole report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one file per table into dir: entities.csv, skills.csv
+// and hybrids.csv hold the win-rate matrices, and the *_overall.csv files
+// the per-row totals.
+func (r *Report) WriteCSV(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, t := range map[string]*Table{"entities": r.Entities, "skills": r.Skills, "hybrids": r.Hybrids} {
+		if err := writeCSVFile(filepath.Join(dir, name+".csv"), t.matrixRecords()); err != nil {
+			return err
+		}
+		if err := writeCSVFile(filepath.Join(dir, name+"_overall.csv"), t.overallRecords()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matrixRecords lays the win rates out with the row labels in the first
+// column and the column labels in the first row; empty cells are pairs
+// that never met.
+func (t *Table) matrixRecords() [][]string {
+	out := [][]string{append([]string{""}, t.Labels...)}
+	for i, l := range t.Labels {
+		row := []string{l}
+		for _, v := range t.WinRate[i] {
+			row = append(row, formatRate(v))
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+func (t *Table) overallRecords() [][]string {
+	out := [][]string{{"label", "games", "wins", "win_rate"}}
+	for _, o := range t.Overall {
+		out = append(out, []string{o.Label, strconv.Itoa(o.Games), strconv.Itoa(o.Wins), formatRate(o.WinRate)})
+	}
+	return out
+}
+
+func formatRate(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 4, 64)
+}
+
+func writeCSVFile(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,151 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/ericogr/chimera-cards/internal/ai"
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Action is the move a bot submits for the current round.
+type Action = ai.Action
+
+// ChooseAction decides the action of the player at idx. It only reads
+// public information: the hybrids in the arena and the bot's own team.
+// Expert bots stand in for the enemies' unrevealed reserves with hybrids
+// drawn from entities.
+func ChooseAction(g *game.Game, idx int, d Difficulty, entities []game.Entity, rng *rand.Rand) Action {
+	p := &g.Players[idx]
+	self := engine.ActiveHybrid(p)
+	if self == nil {
+		return Action{Type: game.PendingActionRest}
+	}
+	switch d {
+	case Expert:
+		if s, err := ai.BestAction(g, idx, ai.Options{Seed: rng.Int63(), Entities: entities}); err == nil {
+			return s.Action
+		}
+		return chooseHard(g, idx, self)
+	case Hard:
+		return chooseHard(g, idx, self)
+	}
+	return chooseEasy(g, idx, self, rng)
+}
+
+// chooseEasy picks uniformly among the legal actions, aiming at a random
+// enemy.
+func chooseEasy(g *game.Game, idx int, self *game.Hybrid, rng *rand.Rand) Action {
+	options := []game.PendingActionType{game.PendingActionBasicAttack, game.PendingActionDefend, game.PendingActionRest}
+	ability := primaryAbility(g, self)
+	if ability != nil {
+		options = append(options, game.PendingActionAbility)
+	}
+	a := Action{Type: options[rng.Intn(len(options))]}
+	if a.Type == game.PendingActionAbility {
+		a.EntityID = ability.ID
+	}
+	if enemies := engine.EnemiesInPlay(g, idx); len(enemies) > 0 {
+		t := enemies[rng.Intn(len(enemies))]
+		a.TargetIndex = &t
+	}
+	return a
+}
+
+// chooseHard follows a fixed priority list:
+//  1. finish an enemy the basic attack is expected to defeat;
+//  2. retreat a badly hurt hybrid when a healthier reserve is available;
+//  3. rest when out of Vigor;
+//  4. defend when the enemies in play are expected to defeat the hybrid;
+//  5. use the primary ability when it is ready and paid in full;
+//  6. otherwise attack.
+//
+// Attacks aim at the enemy with the fewest HP.
+func chooseHard(g *game.Game, idx int, self *game.Hybrid) Action {
+	p := &g.Players[idx]
+	enemies := engine.EnemiesInPlay(g, idx)
+	var target *game.Hybrid
+	var targetIdx *int
+	for _, i := range enemies {
+		h := engine.ActiveHybrid(&g.Players[i])
+		if target == nil || h.CurrentHitPoints < target.CurrentHitPoints {
+			t := i
+			target, targetIdx = h, &t
+		}
+	}
+	attack := Action{Type: game.PendingActionBasicAttack, TargetIndex: targetIdx}
+	if target == nil {
+		return Action{Type: game.PendingActionRest}
+	}
+
+	if self.CurrentVIG > 0 && expectedDamage(self, target) >= target.CurrentHitPoints {
+		return attack
+	}
+	if self.CurrentHitPoints*4 <= self.BaseHitPoints {
+		if r := healthiestReserve(p); r >= 0 && p.Hybrids[r].CurrentHitPoints > self.CurrentHitPoints {
+			return Action{Type: game.PendingActionSwitch, SwitchIndex: &r}
+		}
+	}
+	if self.CurrentVIG == 0 {
+		return Action{Type: game.PendingActionRest}
+	}
+	incoming := 0
+	for _, i := range enemies {
+		incoming += expectedDamage(engine.ActiveHybrid(&g.Players[i]), self)
+	}
+	if incoming >= self.CurrentHitPoints {
+		return Action{Type: game.PendingActionDefend}
+	}
+	if ch := primaryAbility(g, self); ch != nil && self.CurrentVIG >= ch.VigorCost {
+		return Action{Type: game.PendingActionAbility, EntityID: ch.ID, TargetIndex: targetIdx}
+	}
+	return attack
+}
+
+// expectedDamage estimates the damage of a basic attack from attacker on
+// target, ignoring status effects and agility rolls.
+func expectedDamage(attacker, target *game.Hybrid) int {
+	dmg := attacker.CurrentAttack - target.CurrentDefense
+	if dmg < 1 {
+		dmg = 1
+	}
+	if attacker.CurrentVIG == 0 {
+		dmg = (dmg + 1) / 2
+	}
+	return dmg
+}
+
+// primaryAbility returns the hybrid's selected ability when it can be used
+// this round under the rules of g (not on cooldown and affordable),
+// otherwise nil.
+func primaryAbility(g *game.Game, h *game.Hybrid) *game.Entity {
+	if h.SelectedAbilityEntityID == nil {
+		return nil
+	}
+	rules := engine.RulesFor(g)
+	ch := engine.UsableAbility(h, *h.SelectedAbilityEntityID, rules)
+	if ch == nil || engine.AbilityBlocked(h, ch, rules) != "" {
+		return nil
+	}
+	return ch
+}
+
+// healthiestReserve returns the index of the reserve with the most HP, or
+// -1. Reserves that never entered the arena count with their base HP.
+func healthiestReserve(p *game.Player) int {
+	best, bestHP := -1, 0
+	for i := range p.Hybrids {
+		h := &p.Hybrids[i]
+		if h.IsActive || h.IsDefeated {
+			continue
+		}
+		hp := h.CurrentHitPoints
+		if !h.Revealed {
+			hp = h.BaseHitPoints
+		}
+		if hp > bestHP {
+			best, bestHP = i, hp
+		}
+	}
+	return best
+}
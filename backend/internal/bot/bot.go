@@ -0,0 +1,143 @@
+// Package bot implements server-controlled opponents. A bot is a regular
+// game.Player flagged with a difficulty: it builds its hybrids through
+// service.CreateHybrids and plays through service.SubmitAction like any
+// human, so it is bound by the same validation and never sees the
+// opponents' pending actions.
+package bot
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+	"github.com/ericogr/chimera-cards/internal/service"
+	"github.com/google/uuid"
+)
+
+// Difficulty selects how a bot builds its team and picks its actions.
+type Difficulty string
+
+const (
+	// Easy bots build random hybrids and pick random legal actions.
+	Easy Difficulty = "easy"
+	// Hard bots build the strongest hybrids they can and follow a combat
+	// heuristic (finish weak targets, rest before running out of Vigor,
+	// defend against lethal hits, retreat badly hurt hybrids).
+	Hard Difficulty = "hard"
+	// Expert bots build their hybrids like hard bots and search their
+	// actions with package ai.
+	Expert Difficulty = "expert"
+)
+
+// Valid reports whether d is a known difficulty.
+func (d Difficulty) Valid() bool { return d == Easy || d == Hard || d == Expert }
+
+// EmailDomain is the domain of the synthetic e-mail addresses that
+// identify bots inside a game.
+const EmailDomain = "bots.chimera-cards.local"
+
+var (
+	ErrInvalidDifficulty = errors.New("unknown bot difficulty")
+	ErrGameNotWaiting    = errors.New("bots can only join games waiting for players")
+	ErrGameFull          = errors.New("game is full")
+)
+
+// Repo is the repository a bot needs to join a game and play it.
+type Repo interface {
+	service.GameRepo
+	GetEntities() ([]game.Entity, error)
+}
+
+// Join seats a bot of difficulty d in a game waiting for players and
+// creates its hybrids from the configured entities.
+func Join(repo Repo, bus *events.Bus, gameID uint, d Difficulty) (*game.Game, error) {
+	if !d.Valid() {
+		return nil, ErrInvalidDifficulty
+	}
+	g, err := repo.GetGameByID(gameID)
+	if err != nil || g == nil {
+		return nil, service.ErrGameNotFound
+	}
+	if g.Status != game.StatusWaitingForPlayers {
+		return nil, ErrGameNotWaiting
+	}
+	if len(g.Players) >= g.MaxPlayers() {
+		return nil, ErrGameFull
+	}
+	entities, err := repo.GetEntities()
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	specs, err := ChooseTeam(entities, g.HybridsPerPlayer(), d, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	p := game.Player{
+		PlayerName:    "Bot (" + string(d) + ")",
+		PlayerEmail:   "bot-" + strings.SplitN(uuid.NewString(), "-", 2)[0] + "@" + EmailDomain,
+		Team:          g.NextTeam(),
+		BotDifficulty: string(d),
+	}
+	g.Players = append(g.Players, p)
+	g.Message = "A bot joined the game."
+	if err := repo.UpdateGame(g); err != nil {
+		return nil, err
+	}
+	bus.Publish(events.NewPlayerJoined(g.Clone(), len(g.Players)-1))
+	if err := service.CreateHybrids(repo, bus, g.ID, service.CreateHybridsRequest{PlayerEmail: p.PlayerEmail, Hybrids: specs}); err != nil {
+		return nil, err
+	}
+	return repo.GetGameByID(g.ID)
+}
+
+// Play submits the action of every bot in the game that still has to act
+// in the current planning phase. When a bot's action resolves the round,
+// the bots act again for the next one, so after Play returns only humans
+// are left to submit.
+func Play(repo Repo, bus *events.Bus, gameID uint, actionTimeout time.Duration) error {
+	entities, err := repo.GetEntities()
+	if err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		g, err := repo.GetGameByID(gameID)
+		if err != nil || g == nil {
+			return service.ErrGameNotFound
+		}
+		if g.Status != game.StatusInProgress || g.Phase != game.PhasePlanning {
+			return nil
+		}
+		idx := nextToAct(g)
+		if idx < 0 {
+			return nil
+		}
+		p := &g.Players[idx]
+		a := ChooseAction(g, idx, Difficulty(p.BotDifficulty), entities, rng)
+		if a.Type == game.PendingActionSwitch {
+			_, _, err = service.SubmitSwitch(repo, bus, g.ID, p.PlayerEmail, a.SwitchIndex, actionTimeout)
+		} else {
+			_, _, err = service.SubmitTargetedAction(repo, bus, g.ID, p.PlayerEmail, a.Type, a.EntityID, a.TargetIndex, actionTimeout)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// nextToAct returns the index of the first bot that has not submitted its
+// action yet, or -1.
+func nextToAct(g *game.Game) int {
+	for i := range g.Players {
+		p := &g.Players[i]
+		if p.IsBot() && !p.HasSubmittedAction && !p.AllDefeated() {
+			return i
+		}
+	}
+	return -1
+}
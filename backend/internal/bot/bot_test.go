@@ -0,0 +1,244 @@
+package bot
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/ai"
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+	"github.com/ericogr/chimera-cards/internal/service"
+)
+
+type mockRepo struct {
+	g        *game.Game
+	entities []game.Entity
+	rounds   []*game.GameRound
+}
+
+func (m *mockRepo) GetGameByID(id uint) (*game.Game, error) {
+	if m.g == nil || m.g.ID != id {
+		return nil, service.ErrGameNotFound
+	}
+	return m.g, nil
+}
+
+func (m *mockRepo) GetEntitiesByIDs(ids []uint) ([]game.Entity, error) {
+	var out []game.Entity
+	for _, id := range ids {
+		for _, e := range m.entities {
+			if e.ID == id {
+				out = append(out, e)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) GetEntities() ([]game.Entity, error) { return m.entities, nil }
+func (m *mockRepo) UpdateGame(g *game.Game) error       { m.g = g; return nil }
+func (m *mockRepo) UpdateGameWithRound(g *game.Game, r *game.GameRound) error {
+	m.g = g
+	m.rounds = append(m.rounds, r)
+	return nil
+}
+func (m *mockRepo) UpdateStatsOnGameEnd(g *game.Game, resignedEmail string) error { return nil }
+
+func testEntities() []game.Entity {
+	out := make([]game.Entity, 10)
+	for i := range out {
+		out[i] = game.Entity{
+			Name:      string(rune('A' + i)),
+			HitPoints: 3 + i%4,
+			Attack:    4 + i%3,
+			Defense:   2 + i%2,
+			Agility:   1 + i,
+			Energy:    1,
+			VigorCost: 1,
+			Skill: game.Skill{
+				Name:   "Skill " + string(rune('A'+i)),
+				Cost:   1 + i%3,
+				Effect: game.SkillEffect{AttackBuffPercent: 10 * i},
+			},
+		}
+		out[i].ID = uint(i + 1)
+	}
+	return out
+}
+
+func TestChooseTeam_BuildsValidTeams(t *testing.T) {
+	entities := testEntities()
+	for _, d := range []Difficulty{Easy, Hard} {
+		for n := 1; n <= game.MaxHybridCount; n++ {
+			specs, err := ChooseTeam(entities, n, d, rand.New(rand.NewSource(int64(n))))
+			if err != nil {
+				t.Fatalf("%s/%d: unexpected error: %v", d, n, err)
+			}
+			if len(specs) != n {
+				t.Fatalf("%s/%d: expected %d hybrids, got %d", d, n, n, len(specs))
+			}
+			used := map[uint]bool{}
+			for _, s := range specs {
+				if len(s.EntityIDs) < 2 || len(s.EntityIDs) > 3 {
+					t.Fatalf("%s/%d: hybrid with %d entities", d, n, len(s.EntityIDs))
+				}
+				selected := false
+				for _, id := range s.EntityIDs {
+					if used[id] {
+						t.Fatalf("%s/%d: entity %d reused", d, n, id)
+					}
+					used[id] = true
+					selected = selected || id == s.SelectedEntityID
+				}
+				if !selected {
+					t.Fatalf("%s/%d: selected ability %d is not one of the hybrid's entities", d, n, s.SelectedEntityID)
+				}
+			}
+		}
+	}
+	if _, err := ChooseTeam(entities[:3], 2, Easy, rand.New(rand.NewSource(1))); err != ErrNotEnoughEntities {
+		t.Fatalf("expected ErrNotEnoughEntities, got %v", err)
+	}
+}
+
+func TestJoinAndPlay(t *testing.T) {
+	entities := testEntities()
+	g := &game.Game{Status: game.StatusWaitingForPlayers, HybridCount: 2, Players: []game.Player{{PlayerName: "Human", PlayerEmail: "h@example.com"}}}
+	g.ID = 3
+	repo := &mockRepo{g: g, entities: entities}
+
+	if _, err := Join(repo, nil, 3, "nightmare"); err != ErrInvalidDifficulty {
+		t.Fatalf("expected ErrInvalidDifficulty, got %v", err)
+	}
+	if _, err := Join(repo, nil, 3, Hard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := &repo.g.Players[1]
+	if !b.IsBot() || !b.HasCreated || len(b.Hybrids) != 2 {
+		t.Fatalf("expected a bot with 2 hybrids, got %+v", b)
+	}
+	if _, err := Join(repo, nil, 3, Easy); err != ErrGameFull {
+		t.Fatalf("expected ErrGameFull, got %v", err)
+	}
+
+	// The human builds a team and the match starts.
+	specs, _ := ChooseTeam(entities, 2, Easy, rand.New(rand.NewSource(2)))
+	if err := service.CreateHybrids(repo, nil, 3, service.CreateHybridsRequest{PlayerEmail: "h@example.com", Hybrids: specs}); err != nil {
+		t.Fatalf("human hybrids: %v", err)
+	}
+	g = repo.g
+	engine.PrepareMatch(g)
+	g.Status = game.StatusInProgress
+
+	// The bot acts at once and leaves the round to the human.
+	if err := Play(repo, nil, 3, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.g.Players[1].HasSubmittedAction || repo.g.Players[0].HasSubmittedAction {
+		t.Fatalf("expected only the bot to have submitted")
+	}
+	if _, _, err := service.SubmitAction(repo, nil, 3, "h@example.com", game.PendingActionDefend, 0, time.Minute); err != nil {
+		t.Fatalf("human action: %v", err)
+	}
+	if len(repo.rounds) != 1 || repo.g.RoundCount != 2 {
+		t.Fatalf("expected round 1 resolved, rounds=%d round=%d", len(repo.rounds), repo.g.RoundCount)
+	}
+	// Playing again submits the bot's action for round 2.
+	if err := Play(repo, nil, 3, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.g.Status == game.StatusInProgress && !repo.g.Players[1].HasSubmittedAction {
+		t.Fatalf("expected the bot to have acted in round 2")
+	}
+}
+
+func TestChooseHard_FinishesWeakTarget(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{Hybrids: []game.Hybrid{{CurrentHitPoints: 10, BaseHitPoints: 10, CurrentAttack: 6, CurrentDefense: 1, CurrentVIG: 3, IsActive: true}}},
+		{Hybrids: []game.Hybrid{{CurrentHitPoints: 2, BaseHitPoints: 10, CurrentAttack: 3, CurrentDefense: 2, CurrentVIG: 3, IsActive: true}}},
+	}}
+	a := ChooseAction(g, 0, Hard, nil, rand.New(rand.NewSource(1)))
+	if a.Type != game.PendingActionBasicAttack || a.TargetIndex == nil || *a.TargetIndex != 1 {
+		t.Fatalf("expected a basic attack on player 2, got %+v", a)
+	}
+
+	// Out of Vigor and not able to finish the target: rest.
+	g.Players[1].Hybrids[0].CurrentHitPoints = 10
+	g.Players[0].Hybrids[0].CurrentVIG = 0
+	if a := ChooseAction(g, 0, Hard, nil, rand.New(rand.NewSource(1))); a.Type != game.PendingActionRest {
+		t.Fatalf("expected rest, got %+v", a)
+	}
+}
+
+// startedWithBot returns a repo holding game 3, started between a human
+// and a bot of difficulty d that has not acted yet.
+func startedWithBot(t *testing.T, d Difficulty) *mockRepo {
+	t.Helper()
+	entities := testEntities()
+	g := &game.Game{Status: game.StatusWaitingForPlayers, HybridCount: 2, Players: []game.Player{{PlayerName: "Human", PlayerEmail: "h@example.com"}}}
+	g.ID = 3
+	repo := &mockRepo{g: g, entities: entities}
+	if _, err := Join(repo, nil, 3, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	specs, _ := ChooseTeam(entities, 2, Easy, rand.New(rand.NewSource(2)))
+	if err := service.CreateHybrids(repo, nil, 3, service.CreateHybridsRequest{PlayerEmail: "h@example.com", Hybrids: specs}); err != nil {
+		t.Fatalf("human hybrids: %v", err)
+	}
+	engine.PrepareMatch(repo.g)
+	repo.g.Status = game.StatusInProgress
+	return repo
+}
+
+func TestRunner_PlaysWhenPlanningOpens(t *testing.T) {
+	repo := startedWithBot(t, Hard)
+	bus := events.NewBus()
+	r := NewRunner(repo, bus, time.Minute)
+
+	bus.Publish(events.NewGameStarted(repo.g.Clone()))
+	r.wait()
+	if !repo.g.Players[1].HasSubmittedAction || repo.g.Players[0].HasSubmittedAction {
+		t.Fatalf("expected only the bot to have submitted")
+	}
+
+	// The human's action resolves the round; the bot plays the next one.
+	if _, _, err := service.SubmitAction(repo, bus, 3, "h@example.com", game.PendingActionDefend, 0, time.Minute); err != nil {
+		t.Fatalf("human action: %v", err)
+	}
+	r.wait()
+	if repo.g.RoundCount != 2 {
+		t.Fatalf("expected round 1 resolved, round=%d", repo.g.RoundCount)
+	}
+	if repo.g.Status == game.StatusInProgress && !repo.g.Players[1].HasSubmittedAction {
+		t.Fatalf("expected the bot to have acted in round 2")
+	}
+	if len(r.running) != 0 || len(r.again) != 0 {
+		t.Fatalf("expected no run left, got %v %v", r.running, r.again)
+	}
+}
+
+func TestRunner_ExpertSearchesOffTheRequestPath(t *testing.T) {
+	budget := ai.TimeBudget()
+	ai.SetTimeBudget(300 * time.Millisecond)
+	defer ai.SetTimeBudget(budget)
+
+	repo := startedWithBot(t, Expert)
+	bus := events.NewBus()
+	r := NewRunner(repo, bus, time.Minute)
+
+	// The bot missed the start of the round: the human acts first and does
+	// not wait for the bot's search.
+	start := time.Now()
+	if _, resolved, err := service.SubmitAction(repo, bus, 3, "h@example.com", game.PendingActionDefend, 0, time.Minute); err != nil || resolved {
+		t.Fatalf("expected the action stored, got resolved=%v err=%v", resolved, err)
+	}
+	if d := time.Since(start); d >= ai.TimeBudget() {
+		t.Fatalf("the request waited %v for the bot", d)
+	}
+	r.wait()
+	if repo.g.RoundCount != 2 && repo.g.Status == game.StatusInProgress {
+		t.Fatalf("expected the bot's action to resolve round 1, round=%d", repo.g.RoundCount)
+	}
+}
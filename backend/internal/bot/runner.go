@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+	"github.com/ericogr/chimera-cards/internal/logging"
+)
+
+// Runner plays the bots of every game off the request path: when a
+// planning phase opens (the match started or a round was resolved) it runs
+// Play for the game on its own goroutine, so no request waits for a bot to
+// choose its action. Each game has at most one Play running; a planning
+// phase opened meanwhile is played once the current run ends.
+type Runner struct {
+	repo          Repo
+	bus           *events.Bus
+	actionTimeout time.Duration
+
+	mu      sync.Mutex
+	running map[uint]bool
+	again   map[uint]bool
+	wg      sync.WaitGroup
+}
+
+// NewRunner returns a runner that plays the bots of the games whose
+// events are published on bus.
+func NewRunner(repo Repo, bus *events.Bus, actionTimeout time.Duration) *Runner {
+	r := &Runner{repo: repo, bus: bus, actionTimeout: actionTimeout, running: map[uint]bool{}, again: map[uint]bool{}}
+	events.Subscribe(bus, "bots", events.Sync, func(e events.GameStarted) { r.schedule(e.Game) })
+	events.Subscribe(bus, "bots", events.Sync, func(e events.RoundResolved) { r.schedule(e.Game) })
+	// A human acting first in a round the bots missed (e.g. after a
+	// restart) gives them another chance before the deadline.
+	events.Subscribe(bus, "bots", events.Sync, func(e events.ActionSubmitted) {
+		if !e.Game.Players[e.PlayerIndex].IsBot() {
+			r.schedule(e.Game)
+		}
+	})
+	return r
+}
+
+// schedule plays the bots of g unless the match is over or has none.
+func (r *Runner) schedule(g *game.Game) {
+	if g.Status != game.StatusInProgress || !g.HasBots() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[g.ID] {
+		r.again[g.ID] = true
+		return
+	}
+	r.running[g.ID] = true
+	r.wg.Add(1)
+	go r.run(g.ID)
+}
+
+// run plays the bots of the game until no planning phase is left to play.
+func (r *Runner) run(gameID uint) {
+	defer r.wg.Done()
+	for {
+		if err := Play(r.repo, r.bus, gameID, r.actionTimeout); err != nil {
+			// The round deadline still applies to bots.
+			logging.Error("bot failed to submit its action", err, logging.Fields{constants.LogFieldGameID: gameID})
+		}
+		r.mu.Lock()
+		if !r.again[gameID] {
+			delete(r.running, gameID)
+			r.mu.Unlock()
+			return
+		}
+		delete(r.again, gameID)
+		r.mu.Unlock()
+	}
+}
+
+// wait blocks until the scheduled runs are over.
+func (r *Runner) wait() {
+	r.wg.Wait()
+}
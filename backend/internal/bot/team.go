@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+	"github.com/ericogr/chimera-cards/internal/service"
+)
+
+// ErrNotEnoughEntities is returned when the configured entities cannot
+// fill a team of the requested size (every hybrid needs two entities
+// that no other hybrid uses).
+var ErrNotEnoughEntities = errors.New("not enough entities to build the bot's hybrids")
+
+// ChooseTeam picks the entities and primary ability of n hybrids. Easy
+// bots choose at random; hard and expert bots greedily build the highest
+// rated hybrid from the entities still available.
+func ChooseTeam(entities []game.Entity, n int, d Difficulty, rng *rand.Rand) ([]service.CreateHybridSpec, error) {
+	if len(entities) < 2*n {
+		return nil, ErrNotEnoughEntities
+	}
+	pool := append([]game.Entity(nil), entities...)
+	specs := make([]service.CreateHybridSpec, 0, n)
+	for i := 0; i < n; i++ {
+		// Take three entities only when the hybrids still to build keep
+		// at least two each.
+		size := 2
+		if len(pool)-3 >= 2*(n-i-1) {
+			size = 3
+		}
+		var picked []game.Entity
+		if d != Easy {
+			picked = strongestHybrid(pool, size)
+		} else {
+			rng.Shuffle(len(pool), func(a, b int) { pool[a], pool[b] = pool[b], pool[a] })
+			picked = append([]game.Entity(nil), pool[:size]...)
+		}
+		spec := service.CreateHybridSpec{EntityIDs: make([]uint, len(picked))}
+		for k, e := range picked {
+			spec.EntityIDs[k] = e.ID
+		}
+		if d != Easy {
+			spec.SelectedEntityID = bestSkill(picked).ID
+		} else {
+			spec.SelectedEntityID = picked[rng.Intn(len(picked))].ID
+		}
+		specs = append(specs, spec)
+		pool = without(pool, picked)
+	}
+	return specs, nil
+}
+
+// strongestHybrid returns the combination of size entities from pool with
+// the highest hybridRating.
+func strongestHybrid(pool []game.Entity, size int) []game.Entity {
+	var best []game.Entity
+	bestRating := -1
+	var walk func(start int, cur []game.Entity)
+	walk = func(start int, cur []game.Entity) {
+		if len(cur) == size {
+			h := engine.BuildHybrid(cur, cur[0].ID)
+			if r := hybridRating(&h); r > bestRating {
+				bestRating = r
+				best = append([]game.Entity(nil), cur...)
+			}
+			return
+		}
+		for i := start; i < len(pool); i++ {
+			walk(i+1, append(cur, pool[i]))
+		}
+	}
+	walk(0, nil)
+	return best
+}
+
+// hybridRating is a rough measure of a hybrid's strength: how much
+// punishment it can take and deal, plus a bonus for speed.
+func hybridRating(h *game.Hybrid) int {
+	return h.BaseHitPoints*(h.BaseAttack+h.BaseDefense) + 2*h.BaseAgility + 3*h.BaseEnergy
+}
+
+// bestSkill returns the entity with the most valuable skill for its cost.
+func bestSkill(entities []game.Entity) game.Entity {
+	best := entities[0]
+	for _, e := range entities[1:] {
+		if skillValue(e.Skill)*(best.Skill.Cost+1) > skillValue(best.Skill)*(e.Skill.Cost+1) {
+			best = e
+		}
+	}
+	return best
+}
+
+// skillValue scores what a skill does, ignoring its cost.
+func skillValue(s game.Skill) int {
+	eff := s.Effect
+	v := eff.AttackBuffPercent*max(eff.AttackBuffDuration, 1)/10 +
+		eff.OpponentAttackDebuffPercent*max(eff.OpponentAttackDebuffDuration, 1)/10 +
+		eff.OpponentAgilityDebuffPercent*max(eff.OpponentAgilityDebuffDuration, 1)/20 +
+		eff.DefensePenetration()/10 +
+		eff.StunChance/10 +
+		2*eff.Heal + eff.RestoreEnergy +
+		2*eff.DamageOverTime*max(eff.DamageOverTimeDuration, 1) +
+		eff.HealOverTime*max(eff.HealOverTimeDuration, 1) +
+		eff.DefenseBuffMultiplier*max(eff.DefenseBuffDuration, 1)
+	if eff.AgilityDamageDivisor > 0 {
+		v += 3
+	}
+	if eff.Strike {
+		v += 5
+	}
+	if eff.CannotAttack {
+		v -= 2 * max(eff.CannotAttackDuration, 1)
+	}
+	return v
+}
+
+// without returns pool minus the entities in used.
+func without(pool, used []game.Entity) []game.Entity {
+	out := make([]game.Entity, 0, len(pool))
+	for _, e := range pool {
+		keep := true
+		for _, u := range used {
+			if u.ID == e.ID {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, e)
+		}
+	}
+	return out
+}
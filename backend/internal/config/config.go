@@ -21,6 +21,10 @@ type entityEntry struct {
 	VigorCost int    `json:"vigor_cost"`
 	// New nested skill object (name, description, cost, key, effect)
 	Skill game.Skill `json:"skill"`
+	// Optional always-on trait applied to every hybrid using the entity
+	Passive *game.Passive `json:"passive"`
+	// Optional tags used to match synergies (e.g. "predator")
+	Tags []string `json:"tags"`
 }
 
 type rawConfig struct {
@@ -48,6 +52,15 @@ type rawConfig struct {
 	// Accepts a Go duration string (e.g. "1m", "30s") or an integer
 	// number of seconds as fallback. Defaults to "1m" when omitted.
 	ActionTimeout string `json:"action_timeout"`
+	// Optional time the search AI (expert bots and hints) may spend on a
+	// decision. Accepts a Go duration string (e.g. "250ms") or an integer
+	// number of milliseconds as fallback. Defaults to ai.DefaultTimeBudget.
+	AITimeBudget string `json:"ai_time_budget"`
+	// Optional combat ruleset. Omitted fields keep the defaults from
+	// game.DefaultRules.
+	Rules json.RawMessage `json:"rules"`
+	// Optional entity combination bonuses matched when hybrids are built.
+	Synergies []game.Synergy `json:"synergies"`
 }
 
 // LoadedConfig contains entities to seed and the server address to bind to.
@@ -65,6 +78,12 @@ type LoadedConfig struct {
 	PublicGamesTTL time.Duration
 	// How long players have to submit an action each round
 	ActionTimeout time.Duration
+	// How long the search AI may think per decision (0 keeps the default)
+	AITimeBudget time.Duration
+	// Combat ruleset (defaults applied and validated)
+	Rules game.Rules
+	// Entity combination synergies (validated)
+	Synergies []game.Synergy
 }
 
 // LoadConfig reads the configuration file at path and returns entities and
@@ -97,6 +116,8 @@ func LoadConfig(path string) (*LoadedConfig, error) {
 			Energy:    a.Energy,
 			VigorCost: a.VigorCost,
 			Skill:     a.Skill,
+			Passive:   a.Passive,
+			Tags:      a.Tags,
 		})
 	}
 
@@ -106,6 +127,7 @@ func LoadConfig(path string) (*LoadedConfig, error) {
 	// route execution.
 	nameSet := make(map[string]struct{}, len(out))
 	skillSet := make(map[string]struct{}, len(out))
+	passiveSet := make(map[string]struct{}, len(out))
 	for _, aa := range out {
 		ln := strings.ToLower(strings.TrimSpace(aa.Name))
 		if _, exists := nameSet[ln]; exists {
@@ -119,6 +141,53 @@ func LoadConfig(path string) (*LoadedConfig, error) {
 			}
 			skillSet[aa.Skill.Key] = struct{}{}
 		}
+		if aa.Skill.Cost < 0 || aa.Skill.Cooldown < 0 {
+			return nil, fmt.Errorf("config file %s: skill of entity '%s' has a negative cost or cooldown", path, aa.Name)
+		}
+		for i, ce := range aa.Skill.Effect.Conditional {
+			if err := ce.Validate(); err != nil {
+				return nil, fmt.Errorf("config file %s: skill of entity '%s': conditional[%d]: %w", path, aa.Name, i, err)
+			}
+		}
+		if p := aa.Passive; p != nil {
+			if p.Name == "" {
+				return nil, fmt.Errorf("config file %s: passive of entity '%s' is missing 'name'", path, aa.Name)
+			}
+			if e := p.Effect; e.Thorns < 0 || e.VigorRegen < 0 || e.BonusVsRestingPercent < 0 ||
+				e.DefendDamageReductionPercent < 0 || e.DefendDamageReductionPercent > 100 {
+				return nil, fmt.Errorf("config file %s: passive of entity '%s' has an out-of-range effect", path, aa.Name)
+			}
+			if p.Key != "" {
+				if _, exists := passiveSet[p.Key]; exists {
+					return nil, fmt.Errorf("config file %s: duplicate passive key '%s'", path, p.Key)
+				}
+				passiveSet[p.Key] = struct{}{}
+			}
+		}
+	}
+
+	// Synergies must have a unique key, at least one requirement and only
+	// reference configured entities.
+	synergyKeys := make(map[string]struct{}, len(rc.Synergies))
+	for _, s := range rc.Synergies {
+		if s.Name == "" || s.Key == "" {
+			return nil, fmt.Errorf("config file %s: synergy entry missing 'name' or 'key'", path)
+		}
+		if _, exists := synergyKeys[s.Key]; exists {
+			return nil, fmt.Errorf("config file %s: duplicate synergy key '%s'", path, s.Key)
+		}
+		synergyKeys[s.Key] = struct{}{}
+		if len(s.Entities) == 0 && s.Tag == "" {
+			return nil, fmt.Errorf("config file %s: synergy '%s' needs 'entities' or 'tag'", path, s.Key)
+		}
+		if s.MinCount < 0 || s.MinCount > 3 {
+			return nil, fmt.Errorf("config file %s: synergy '%s' has an invalid min_count", path, s.Key)
+		}
+		for _, n := range s.Entities {
+			if _, ok := nameSet[strings.ToLower(strings.TrimSpace(n))]; !ok {
+				return nil, fmt.Errorf("config file %s: synergy '%s' references unknown entity '%s'", path, s.Key, n)
+			}
+		}
 	}
 
 	addr := ":8080"
@@ -159,6 +228,33 @@ func LoadConfig(path string) (*LoadedConfig, error) {
 		}
 	}
 
+	// Parse the AI time budget; zero keeps the package default.
+	var aiBudget time.Duration
+	if txt := strings.TrimSpace(rc.AITimeBudget); txt != "" {
+		if d, err := time.ParseDuration(txt); err == nil {
+			aiBudget = d
+		} else if ms, serr := strconv.Atoi(txt); serr == nil {
+			aiBudget = time.Duration(ms) * time.Millisecond
+		} else {
+			return nil, fmt.Errorf("config file %s: invalid ai_time_budget: %w", path, err)
+		}
+		if aiBudget <= 0 {
+			return nil, fmt.Errorf("config file %s: ai_time_budget must be positive", path)
+		}
+	}
+
+	// Parse the ruleset on top of the defaults so partial sections work
+	// (a given fatigue.defense_loss replaces the default schedule).
+	rules := game.DefaultRules()
+	if len(rc.Rules) > 0 {
+		if err := json.Unmarshal(rc.Rules, &rules); err != nil {
+			return nil, fmt.Errorf("config file %s: invalid rules: %w", path, err)
+		}
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("config file %s: invalid rules: %w", path, err)
+	}
+
 	return &LoadedConfig{
 		Entities:                  out,
 		ServerAddress:             addr,
@@ -167,6 +263,9 @@ func LoadConfig(path string) (*LoadedConfig, error) {
 		NamePromptTemplate:        strings.TrimSpace(rc.NamePrompt),
 		PublicGamesTTL:            ttl,
 		ActionTimeout:             actionTimeout,
+		AITimeBudget:              aiBudget,
+		Rules:                     rules,
+		Synergies:                 rc.Synergies,
 	}, nil
 }
 
@@ -70,6 +70,12 @@ const (
 	RouteGameLeave          = "/games/:gameCode/leave"
 	RouteCreateHybrids      = "/games/:gameCode/create-hybrids"
 	RouteGameAction         = "/games/:gameCode/action"
+	RouteGameNextReserve    = "/games/:gameCode/next-reserve"
+	RouteGameBots           = "/games/:gameCode/bots"
+	RouteGameHint           = "/games/:gameCode/hint"
+	RouteGameRounds         = "/games/:gameCode/rounds"
+	RouteGameReplay         = "/games/:gameCode/replay"
+	RouteGameEvents         = "/games/:gameCode/events"
 )
 
 // Common JSON response keys
@@ -79,6 +85,8 @@ const (
 	JSONKeyDetails = "details"
 	JSONKeyStatus  = "status"
 	JSONKeyBody    = "body"
+	// JSONKeyCode carries a machine-readable reason next to some errors.
+	JSONKeyCode = "code"
 )
 
 // Common error messages used across API handlers
@@ -94,13 +102,16 @@ const (
 	ErrFailedEncodeGame       = "Failed to encode game"
 	ErrFailedFetchStats       = "Failed to fetch stats"
 	ErrEmailRequired          = "email is required"
+	ErrGameNotFinished        = "Round history is only available for finished games"
+	ErrFailedFetchRounds      = "Failed to fetch round history"
+	ErrFailedExportReplay     = "Failed to export replay"
 
 	ErrFailedCreateGame             = "Failed to create game"
 	ErrGameNameExceeds              = "Game name exceeds 32 characters"
 	ErrDescriptionExceeds           = "Description exceeds 256 characters"
 	ErrGameFull                     = "Game is full"
 	ErrNotEnoughPlayers             = "Not enough players to start the game"
-	ErrBothPlayersMustCreateHybrids = "Both players must create hybrids before starting"
+	ErrBothPlayersMustCreateHybrids = "Every player must create hybrids before starting"
 	ErrGameAlreadyStartingOrStarted = "Game is already starting or started"
 	ErrFailedUpdateGame             = "Failed to update game"
 	ErrFailedUpdateGameStatus       = "Failed to update game status"
@@ -109,6 +120,12 @@ const (
 	ErrPlayerNotInThisGame          = "Player not in this game"
 	ErrPlayerRemovedFailedUpdate    = "Player removed, but failed to update game"
 	ErrCannotLeaveAfterGameStarted  = "Cannot leave after the game has started"
+	ErrInvalidGameMode              = "Unknown game mode"
+	ErrInvalidBotDifficulty         = "Unknown bot difficulty"
+	ErrBotsOnlyBeforeStart          = "Bots can only join games waiting for players"
+	ErrFailedAddBot                 = "Failed to add bot"
+	ErrHintsOnlyInPractice          = "Hints are only available in practice games against bots"
+	ErrNoHintAvailable              = "There is no action to suggest right now"
 
 	ErrHybridsAlreadyCreated   = "Hybrids already created"
 	ErrFailedSaveHybrids       = "Failed to save hybrids"
@@ -119,6 +136,13 @@ const (
 	ErrActionsLockedResolvingRound = "Actions are locked; resolving current round"
 	ErrPlayerNotInGame             = "Player not in game"
 	ErrNoActiveHybrid              = "No active hybrid"
+	ErrHybridStunned               = "Your hybrid is stunned and skips this round"
+	ErrInvalidSwitch               = "No valid reserve hybrid to switch to"
+	ErrNotEnoughEnergy             = "Not enough Energy for this ability"
+	ErrInvalidReserve              = "Not a valid reserve hybrid"
+	ErrInvalidHybridCountSetting   = "Hybrid count must be between 1 and 5"
+	ErrAbilityOnCooldown           = "This ability is on cooldown"
+	ErrInvalidTarget               = "Target is not an enemy in play"
 
 	ErrFailedExchangeToken    = "Failed to exchange token"
 	ErrFailedGetUserInfo      = "Failed to get user info"
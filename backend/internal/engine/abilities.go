@@ -0,0 +1,76 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// Reasons reported by AbilityBlocked (and by ability_failed events).
+const (
+	AbilityNotEnoughEnergy = "not_enough_energy"
+	AbilityOnCooldown      = "on_cooldown"
+)
+
+// UsableAbility returns the base entity of h whose skill the hybrid may
+// use as entityID under r, or nil. The primary ability (the entity
+// selected at creation) is always usable; the others only when
+// r.Abilities.AllUsable is set.
+func UsableAbility(h *game.Hybrid, entityID uint, r game.Rules) *game.Entity {
+	ch := getChosen(h, &entityID)
+	if ch == nil {
+		return nil
+	}
+	if isPrimaryAbility(h, ch) || r.Abilities.AllUsable {
+		return ch
+	}
+	return nil
+}
+
+// AbilityCost returns the Energy h pays to use the skill of ch: its cost,
+// plus the secondary surcharge for any skill but the primary one when all
+// abilities are usable.
+func AbilityCost(h *game.Hybrid, ch *game.Entity, r game.Rules) int {
+	if !r.Abilities.AllUsable || isPrimaryAbility(h, ch) {
+		return ch.Skill.Cost
+	}
+	return ch.Skill.Cost + r.Abilities.SecondaryEnergySurcharge
+}
+
+func isPrimaryAbility(h *game.Hybrid, ch *game.Entity) bool {
+	return h.SelectedAbilityEntityID != nil && *h.SelectedAbilityEntityID == ch.ID
+}
+
+// AbilityBlocked returns why h cannot use the ability of entity ch this
+// round under r, or "" when it can. Whether ch is usable at all is
+// checked by UsableAbility.
+func AbilityBlocked(h *game.Hybrid, ch *game.Entity, r game.Rules) string {
+	if h.AbilityCooldowns[ch.ID] > 0 {
+		return AbilityOnCooldown
+	}
+	if h.CurrentEnergy < AbilityCost(h, ch, r) {
+		return AbilityNotEnoughEnergy
+	}
+	return ""
+}
+
+// checkAbility turns an ability the active hybrid cannot use this round
+// into a skipped action. SubmitAction rejects such abilities; this guards
+// replays and actions stored before the hybrid's state changed.
+func (rc *roundContext) checkAbility(p *game.Player, h *game.Hybrid) {
+	if p.PendingActionType != game.PendingActionAbility {
+		return
+	}
+	ch := getChosen(h, p.PendingActionEntityID)
+	if ch == nil {
+		return
+	}
+	reason := AbilityBlocked(h, ch, rc.rules)
+	if reason == "" {
+		return
+	}
+	p.PendingActionType = game.PendingActionSkip
+	ev := rc.event(game.EventAbilityFailed, p, h)
+	ev.Action = game.PendingActionAbility
+	ev.Skill = ch.Skill.Name
+	ev.SkillKey = ch.Skill.Key
+	ev.Reason = reason
+	ev.Duration = h.AbilityCooldowns[ch.ID]
+	rc.emit(ev)
+}
@@ -2,71 +2,111 @@ package engine
 
 import (
 	"math"
-	"strconv"
-	"strings"
 
 	"github.com/ericogr/chimera-cards/internal/game"
 )
 
-func (rc *roundContext) execBasicAttack(plan *plannedAction, oppPlayer *game.Player) {
+// execAttack resolves a basic attack or an ability strike against the
+// plan's target and emits the resulting damage_dealt event.
+func (rc *roundContext) execAttack(plan *plannedAction, oppPlayer *game.Player) {
+	attackEvent := func(t game.RoundEventType) game.RoundEvent {
+		ev := rc.withTarget(rc.event(t, plan.player, plan.actor), oppPlayer, plan.target)
+		ev.Action = game.PendingActionBasicAttack
+		if plan.action == ActionAbility && plan.entity != nil {
+			ev.Action = game.PendingActionAbility
+			ev.Skill = plan.entity.Skill.Name
+			ev.SkillKey = plan.entity.Skill.Key
+		}
+		return ev
+	}
+
+	// Agility rolls: the faster target may dodge, the faster attacker may
+	// land a critical hit. Rolls are only made when the chance is positive.
+	agiLead := agilityWithModifiers(plan.actor, rc.g.RoundCount) - agilityWithModifiers(plan.target, rc.g.RoundCount)
+	dodge := rc.rules.Dodge.Chance(-agiLead)
+	if dodge > 0 && rc.rng.Intn(100) < dodge {
+		ev := attackEvent(game.EventAttackDodged)
+		ev.Percent = dodge
+		rc.emit(ev)
+		return
+	}
+	crit := rc.rules.Critical.Chance(agiLead)
+	critical := crit > 0 && rc.rng.Intn(100) < crit
+
 	atqEff := attackWithModifiers(plan.actor, rc.g.RoundCount)
-	defEff := defenseWithModifiers(plan.target)
-	ignored := false
-	if plan.actor.AttackIgnoresDefenseThisRound {
-		defEff = 0
-		ignored = true
+	bd := &game.DamageBreakdown{}
+	bd.AgilityBonus = agilityDamageBonus(plan.actor, rc.g.RoundCount)
+	atqEff += bd.AgilityBonus
+	bd.Attack = atqEff
+	defEff := defenseWithModifiers(plan.target, rc.g.RoundCount, rc.rules)
+	if plan.target.DefendStanceActive {
+		bd.DefenseModifiers = append(bd.DefenseModifiers, game.DefenseSourceDefendStance)
 	}
+	if plan.target.HasStatus(game.StatusDefenseMultiplier, rc.g.RoundCount) {
+		bd.DefenseModifiers = append(bd.DefenseModifiers, game.DefenseSourceDefenseBuff)
+	}
+	if pen := defensePenetration(plan.actor, rc.g.RoundCount); pen > 0 {
+		ignored := defEff * pen / 100
+		defEff -= ignored
+		bd.DefenseIgnored = ignored
+		bd.IgnoredPercent = pen
+		bd.DefenseModifiers = append(bd.DefenseModifiers, game.DefenseSourceIgnoreDefense)
+	}
+	bd.Defense = defEff
 	raw := atqEff - defEff
 	if raw < 1 {
 		raw = 1
 	}
+	bd.Base = raw
+	bd.DodgeChance = dodge
+	bd.CriticalChance = crit
 	dmg := raw
-	halved := false
+	if critical {
+		f := rc.rules.Critical.Multiplier
+		dmg = int(math.Ceil(float64(dmg) * f))
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceCritical, Factor: f})
+	}
+	if pct := effectTotal(plan.actor, game.StatusDamageBonus, rc.g.RoundCount); pct > 0 {
+		f := 1 + float64(pct)/100
+		dmg = int(math.Ceil(float64(dmg) * f))
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceConditional, Factor: f})
+	}
+	if pct, _ := passiveTotal(plan.actor, func(e game.PassiveEffect) int { return e.BonusVsRestingPercent }); pct > 0 && oppPlayer.PendingActionType == game.PendingActionRest {
+		f := 1 + float64(pct)/100
+		dmg = int(math.Ceil(float64(dmg) * f))
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceRestingTarget, Factor: f})
+	}
 	if plan.actor.AttackHalvedThisRound {
-		dmg = int(math.Floor(float64(dmg) * 0.5))
+		f := rc.rules.ZeroVigorMultiplier
+		dmg = int(math.Floor(float64(dmg) * f))
 		if dmg < 1 {
 			dmg = 1
 		}
-		halved = true
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceZeroVigor, Factor: f})
 	}
-	appliedVuln := false
 	if plan.target.VulnerableThisRound {
-		dmg = int(math.Ceil(float64(dmg) * 1.25))
-		appliedVuln = true
+		f := rc.rules.VulnerableMultiplier
+		dmg = int(math.Ceil(float64(dmg) * f))
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceVulnerable, Factor: f})
 	}
-	plan.target.CurrentHitPoints -= dmg
-	rc.add(plan.player.PlayerName + " BASIC ATTACK — Calculation: Attack " + strconv.Itoa(atqEff) + ", Defense " + strconv.Itoa(defEff) + func() string {
-		if ignored {
-			return " (defense ignored)"
-		}
-		return ""
-	}() + "; base damage " + strconv.Itoa(raw) + func() string {
-		if halved {
-			return " (halved due to 0 VIG)"
-		}
-		return ""
-	}() + func() string {
-		if appliedVuln {
-			return "; +25% vs Vulnerable"
+	if pct, _ := passiveTotal(plan.target, func(e game.PassiveEffect) int { return e.DefendDamageReductionPercent }); pct > 0 && plan.target.DefendStanceActive {
+		f := 1 - float64(pct)/100
+		dmg = int(math.Floor(float64(dmg) * f))
+		if dmg < 1 {
+			dmg = 1
 		}
-		return ""
-	}() + "; final damage " + strconv.Itoa(dmg))
-	ctxParts := []string{}
-	if plan.target.DefendStanceActive {
-		ctxParts = append(ctxParts, "defend bonus")
-	}
-	if plan.target.DefenseBuffMultiplier > 0 && plan.target.DefenseBuffUntilRound >= rc.g.RoundCount {
-		ctxParts = append(ctxParts, "Iron Shell")
+		bd.Multipliers = append(bd.Multipliers, game.DamageModifier{Source: game.DamageSourceDefendPassive, Factor: f})
 	}
-	if ignored {
-		ctxParts = append(ctxParts, "ignored defense")
-	}
-	if appliedVuln {
-		ctxParts = append(ctxParts, "+25% vs Vulnerable")
-	}
-	ctx := ""
-	if len(ctxParts) > 0 {
-		ctx = " (" + strings.Join(ctxParts, ", ") + ")"
+	bd.Final = dmg
+	plan.target.CurrentHitPoints -= dmg
+
+	ev := attackEvent(game.EventDamageDealt)
+	ev.Damage = bd
+	rc.emit(ev)
+
+	if plan.action == ActionAbility && plan.entity != nil && plan.target.CurrentHitPoints > 0 {
+		rc.tryStun(plan.player, plan.actor, plan.entity, plan.target)
 	}
-	rc.add(oppPlayer.PlayerName + "'s " + hybridDisplayName(plan.target) + " takes " + strconv.Itoa(dmg) + " damage" + ctx)
+	rc.applyThorns(oppPlayer, plan.target, plan.player, plan.actor)
+	rc.applyReflect(oppPlayer, plan.target, plan.player, plan.actor, dmg)
 }
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// conditionHolds evaluates c for player's hybrid self against the
+// opponent's revealed action and active hybrid opp.
+func (rc *roundContext) conditionHolds(c game.SkillCondition, player *game.Player, self, opp *game.Hybrid) bool {
+	if c.OpponentAction != "" {
+		oppPlayer := rc.opponentOf(player)
+		if c.OpponentAction == game.ConditionOpponentAttacks {
+			if !attacksThisRound(oppPlayer, opp) {
+				return false
+			}
+		} else if string(oppPlayer.PendingActionType) != c.OpponentAction {
+			return false
+		}
+	}
+	if c.SelfHPBelowPercent > 0 && !self.HPBelow(c.SelfHPBelowPercent) {
+		return false
+	}
+	if c.OpponentHPBelowPercent > 0 && !opp.HPBelow(c.OpponentHPBelowPercent) {
+		return false
+	}
+	return true
+}
+
+// attacksThisRound reports whether the player's action strikes the
+// opponent: a basic attack or a striking ability.
+func attacksThisRound(p *game.Player, h *game.Hybrid) bool {
+	switch p.PendingActionType {
+	case game.PendingActionBasicAttack:
+		return true
+	case game.PendingActionAbility:
+		ch := getChosen(h, p.PendingActionEntityID)
+		return ch != nil && ch.Skill.Effect.Strike
+	}
+	return false
+}
+
+// applyConditionalEffects applies the conditional effects of the ability
+// ch whose condition holds this round.
+func (rc *roundContext) applyConditionalEffects(player *game.Player, self, opp *game.Hybrid, ch *game.Entity) {
+	for _, ce := range ch.Skill.Effect.Conditional {
+		if !rc.conditionHolds(ce.When, player, self, opp) {
+			continue
+		}
+		when := ce.When
+		skillEvent := func(t game.RoundEventType) game.RoundEvent {
+			ev := rc.event(t, player, self)
+			ev.Action = game.PendingActionAbility
+			ev.Skill = ch.Skill.Name
+			ev.SkillKey = ch.Skill.Key
+			ev.Condition = &when
+			return ev
+		}
+		if ce.DamageBonusPercent > 0 {
+			rc.applyStatus(self, ch, game.StatusDamageBonus, ce.DamageBonusPercent, rc.g.RoundCount, 1)
+			ev := skillEvent(game.EventBuffApplied)
+			ev.Effect = game.EffectDamageBonus
+			ev.Percent = ce.DamageBonusPercent
+			ev.Duration = 1
+			rc.emit(ev)
+		}
+		if ce.ReflectPercent > 0 {
+			rc.applyStatus(self, ch, game.StatusReflect, ce.ReflectPercent, rc.g.RoundCount, 1)
+			ev := skillEvent(game.EventBuffApplied)
+			ev.Effect = game.EffectReflect
+			ev.Percent = ce.ReflectPercent
+			ev.Duration = 1
+			rc.emit(ev)
+		}
+		if ce.Heal > 0 {
+			ev := skillEvent(game.EventHealed)
+			ev.Amount = heal(self, ce.Heal)
+			rc.emit(ev)
+		}
+	}
+}
+
+// applyReflect returns part of the dmg that target just took from
+// attacker when target carries a reflect effect.
+func (rc *roundContext) applyReflect(targetPlayer *game.Player, target *game.Hybrid, attackerPlayer *game.Player, attacker *game.Hybrid, dmg int) {
+	pct := effectTotal(target, game.StatusReflect, rc.g.RoundCount)
+	if pct <= 0 || dmg <= 0 {
+		return
+	}
+	back := int(math.Ceil(float64(dmg) * float64(pct) / 100))
+	attacker.CurrentHitPoints -= back
+	ev := rc.withTarget(rc.event(game.EventDamageReflected, targetPlayer, target), attackerPlayer, attacker)
+	ev.Skill = target.ActiveEffects(game.StatusReflect, rc.g.RoundCount)[0].SourceName
+	ev.Percent = pct
+	ev.Amount = back
+	rc.emit(ev)
+}
@@ -1,28 +1,94 @@
 package engine
 
-import (
-	"strings"
-
-	"github.com/ericogr/chimera-cards/internal/game"
-)
+import "github.com/ericogr/chimera-cards/internal/game"
 
 // --- Round context and helpers ----------------------------------------
 type roundContext struct {
-	g       *game.Game
-	summary []string
+	g      *game.Game
+	events []game.RoundEvent
+	// rng is the only source of randomness used while resolving the
+	// round. Unless a caller supplies its own (ResolveRoundWithSource), it
+	// is derived from the game's seed and round number so the same state
+	// and actions always resolve the same way.
+	rng RandomSource
+	// rules are the combat numbers in effect for the round.
+	rules game.Rules
+	// targets maps every combatant to the enemy its action is aimed at
+	// (see lockTargets).
+	targets map[*game.Player]combatant
+	// startHP is every hybrid's HP when the round began and fallen the
+	// hybrids defeated during the round, in order; tiebreaks use them.
+	startHP map[*game.Hybrid]int
+	fallen  []combatant
+}
+
+func newRoundContext(g *game.Game, r game.Rules, rng RandomSource) *roundContext {
+	rc := &roundContext{g: g, events: make([]game.RoundEvent, 0, 16), rng: rng, rules: r, startHP: map[*game.Hybrid]int{}}
+	for i := range g.Players {
+		for j := range g.Players[i].Hybrids {
+			h := &g.Players[i].Hybrids[j]
+			rc.startHP[h] = h.CurrentHitPoints
+		}
+	}
+	return rc
 }
 
-func newRoundContext(g *game.Game) *roundContext {
-	return &roundContext{g: g, summary: make([]string, 0, 16)}
+// defeat takes h out of the match when it has no HP left. It reports
+// whether h fell now.
+func (rc *roundContext) defeat(p *game.Player, h *game.Hybrid) bool {
+	if h.IsDefeated || h.CurrentHitPoints > 0 {
+		return false
+	}
+	h.IsDefeated = true
+	h.IsActive = false
+	rc.fallen = append(rc.fallen, combatant{player: p, hybrid: h})
+	rc.emit(rc.event(game.EventHybridDefeated, p, h))
+	return true
+}
+
+// emit records an event for the round being resolved.
+func (rc *roundContext) emit(ev game.RoundEvent) {
+	ev.Round = rc.g.RoundCount
+	rc.events = append(rc.events, ev)
 }
 
-func (rc *roundContext) add(msg string) { rc.summary = append(rc.summary, msg) }
+// playerIndex returns the position of p inside the game's player list.
+func (rc *roundContext) playerIndex(p *game.Player) int {
+	return playerIndex(rc.g, p)
+}
 
-func (rc *roundContext) vulnerableTag(h *game.Hybrid) string {
-	if h.VulnerableThisRound {
-		return " — becomes Vulnerable (+25% damage this round)"
+func playerIndex(g *game.Game, p *game.Player) int {
+	for i := range g.Players {
+		if &g.Players[i] == p {
+			return i
+		}
 	}
-	return ""
+	return -1
+}
+
+// opponentOf returns the enemy player p targets this round.
+func (rc *roundContext) opponentOf(p *game.Player) *game.Player {
+	return rc.targets[p].player
+}
+
+// opponentHybrid returns the hybrid p targets this round (the one that
+// was in the arena when targets were locked).
+func (rc *roundContext) opponentHybrid(p *game.Player) *game.Hybrid {
+	return rc.targets[p].hybrid
+}
+
+// event builds an event of type t attributed to player p and its hybrid h.
+func (rc *roundContext) event(t game.RoundEventType, p *game.Player, h *game.Hybrid) game.RoundEvent {
+	return game.RoundEvent{Type: t, PlayerIndex: rc.playerIndex(p), Player: p.PlayerName, Hybrid: hybridDisplayName(h)}
+}
+
+// withTarget fills the target fields of ev.
+func (rc *roundContext) withTarget(ev game.RoundEvent, p *game.Player, h *game.Hybrid) game.RoundEvent {
+	idx := rc.playerIndex(p)
+	ev.TargetIndex = &idx
+	ev.Target = p.PlayerName
+	ev.TargetHybrid = hybridDisplayName(h)
+	return ev
 }
 
 func (rc *roundContext) minInt(a, b int) int {
@@ -32,7 +98,9 @@ func (rc *roundContext) minInt(a, b int) int {
 	return b
 }
 
-// joinSummary returns the accumulated summary as a single string.
-func (rc *roundContext) joinSummary() string {
-	return strings.Join(rc.summary, "\n")
+// publish stores the round's events on the game together with the text
+// summary derived from them.
+func (rc *roundContext) publish() {
+	rc.g.LastRoundEvents = rc.events
+	rc.g.LastRoundSummary = SummarizeEvents(rc.events)
 }
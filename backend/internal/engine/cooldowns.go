@@ -0,0 +1,29 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// startCooldown puts the ability of ch on cooldown after h used it.
+func startCooldown(h *game.Hybrid, ch *game.Entity) {
+	if ch.Skill.Cooldown <= 0 {
+		return
+	}
+	if h.AbilityCooldowns == nil {
+		h.AbilityCooldowns = make(map[uint]int)
+	}
+	h.AbilityCooldowns[ch.ID] = ch.Skill.Cooldown
+}
+
+// tickCooldowns consumes one round from every ability cooldown of the
+// player's hybrids (reserves included) as the round resolves.
+func tickCooldowns(p *game.Player) {
+	for i := range p.Hybrids {
+		h := &p.Hybrids[i]
+		for id, n := range h.AbilityCooldowns {
+			if n <= 1 {
+				delete(h.AbilityCooldowns, id)
+			} else {
+				h.AbilityCooldowns[id] = n - 1
+			}
+		}
+	}
+}
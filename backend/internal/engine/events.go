@@ -0,0 +1,272 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// SummarizeEvents renders a round's events as the newline-separated text
+// stored in Game.LastRoundSummary.
+func SummarizeEvents(events []game.RoundEvent) string {
+	lines := make([]string, 0, len(events))
+	for _, ev := range events {
+		if s := DescribeEvent(ev); s != "" {
+			lines = append(lines, s)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DescribeEvent returns the English log line for a single event.
+func DescribeEvent(ev game.RoundEvent) string {
+	itoa := strconv.Itoa
+	switch ev.Type {
+	case game.EventCostPaid:
+		switch ev.Action {
+		case game.PendingActionBasicAttack:
+			if ev.Vigor > 0 {
+				return ev.Player + " BASIC ATTACK: spent " + itoa(ev.Vigor) + " VIG"
+			}
+			if ev.Percent > 0 && ev.Percent != 50 {
+				return ev.Player + " BASIC ATTACK: 0 VIG — damage reduced to " + itoa(ev.Percent) + "%"
+			}
+			return ev.Player + " BASIC ATTACK: 0 VIG — damage will be halved"
+		case game.PendingActionDefend:
+			if ev.Vigor > 0 {
+				return ev.Player + " DEFEND: spent " + itoa(ev.Vigor) + " VIG (+" + itoa(percentOr(ev.Percent, 50)) + "% Defense this round)"
+			}
+			return ev.Player + " DEFEND: 0 VIG — no defense bonus"
+		case game.PendingActionAbility:
+			s := ev.Player + " ABILITY — " + ev.Skill + ": Costs: Energy " + itoa(ev.Energy) + ", Vigor " + itoa(ev.Vigor)
+			if ev.Vulnerable {
+				s += " — becomes Vulnerable (+" + itoa(percentOr(ev.Percent, 25)) + "% damage this round)"
+			}
+			if ev.Duration > 0 {
+				s += " — cooldown " + itoa(ev.Duration) + " round(s)"
+			}
+			return s
+		}
+	case game.EventBuffApplied, game.EventDebuffApplied:
+		prefix := ev.Player + " ABILITY — " + ev.Skill + ": "
+		if ev.Condition != nil {
+			prefix += "(" + describeCondition(*ev.Condition) + ") "
+		}
+		rounds := " for " + itoa(ev.Duration) + " round(s)"
+		switch ev.Effect {
+		case game.EffectAttackBuff:
+			return prefix + "+" + itoa(ev.Percent) + "% Attack" + rounds
+		case game.EffectAttackDebuff:
+			return prefix + "-" + itoa(ev.Percent) + "% opponent Attack" + rounds
+		case game.EffectAgilityDebuff:
+			return prefix + "-" + itoa(ev.Percent) + "% opponent Agility" + rounds
+		case game.EffectDefenseMultiplier:
+			return prefix + "Defense x" + itoa(ev.Multiplier) + rounds
+		case game.EffectIgnoreDefense:
+			if ev.Percent > 0 && ev.Percent < 100 {
+				return prefix + "ignores " + itoa(ev.Percent) + "% of opponent Defense" + rounds
+			}
+			return prefix + "ignores Defense" + rounds
+		case game.EffectAgilityDamage:
+			return prefix + "+1 Attack per " + itoa(ev.Divisor) + " Agility" + rounds
+		case game.EffectCannotAttack:
+			return prefix + "cannot attack" + rounds
+		case game.EffectDamageOverTime:
+			return prefix + ev.Target + "'s " + ev.TargetHybrid + " will lose " + itoa(ev.Amount) + " HP per round" + rounds
+		case game.EffectHealOverTime:
+			return prefix + "regenerates " + itoa(ev.Amount) + " HP per round" + rounds
+		case game.EffectDamageBonus:
+			return prefix + "+" + itoa(ev.Percent) + "% damage" + rounds
+		case game.EffectReflect:
+			return prefix + "reflects " + itoa(ev.Percent) + "% of damage taken" + rounds
+		case game.EffectStun:
+			return prefix + ev.Target + "'s " + ev.TargetHybrid + " is stunned" + rounds + " (" + itoa(ev.Percent) + "% chance)"
+		}
+	case game.EventAbilityFailed:
+		if ev.Reason == AbilityOnCooldown {
+			return ev.Player + " ABILITY — " + ev.Skill + ": on cooldown (" + itoa(ev.Duration) + " round(s) left), action skipped"
+		}
+		return ev.Player + " ABILITY — " + ev.Skill + ": not enough Energy, action skipped"
+	case game.EventEnergyRestored:
+		return ev.Player + " ABILITY — " + ev.Skill + ": +" + itoa(ev.Energy) + " Energy"
+	case game.EventHealed:
+		if ev.Effect == game.EffectHealOverTime {
+			return ev.Player + "'s " + ev.Hybrid + " regenerates " + itoa(ev.Amount) + " HP (" + ev.Skill + ")"
+		}
+		if ev.Condition != nil {
+			return ev.Player + " ABILITY — " + ev.Skill + ": (" + describeCondition(*ev.Condition) + ") heals " + itoa(ev.Amount) + " HP"
+		}
+		return ev.Player + " ABILITY — " + ev.Skill + ": heals " + itoa(ev.Amount) + " HP"
+	case game.EventDamageReflected:
+		return ev.Player + "'s " + ev.Hybrid + " (" + ev.Skill + ") reflects " + itoa(ev.Amount) + " damage to " + ev.Target + "'s " + ev.TargetHybrid
+	case game.EventStatusDamage:
+		return ev.Player + "'s " + ev.Hybrid + " takes " + itoa(ev.Amount) + " damage over time (" + ev.Skill + ")"
+	case game.EventRested:
+		return ev.Player + " REST: +" + itoa(ev.Vigor) + " VIG, +" + itoa(ev.Energy) + " ENE (VIG capped at base)"
+	case game.EventStunResisted:
+		return ev.Player + " ABILITY — " + ev.Skill + ": " + ev.Target + "'s " + ev.TargetHybrid + " resists the stun (" + itoa(ev.Percent) + "% chance)"
+	case game.EventStunned:
+		return ev.Player + "'s " + ev.Hybrid + " is stunned and cannot act"
+	case game.EventDamageDealt:
+		return describeDamage(ev)
+	case game.EventAttackDodged:
+		label := " BASIC ATTACK"
+		if ev.Action == game.PendingActionAbility {
+			label = " ABILITY — " + ev.Skill
+		}
+		return ev.Player + label + ": " + ev.Target + "'s " + ev.TargetHybrid + " dodges the attack (" + itoa(ev.Percent) + "% dodge chance)"
+	case game.EventPassiveTriggered:
+		switch ev.Effect {
+		case game.PassiveThorns:
+			return ev.Player + "'s " + ev.Hybrid + " PASSIVE — " + ev.Skill + ": " + ev.Target + "'s " + ev.TargetHybrid + " takes " + itoa(ev.Amount) + " thorns damage"
+		case game.PassiveVigorRegen:
+			return ev.Player + "'s " + ev.Hybrid + " PASSIVE — " + ev.Skill + ": +" + itoa(ev.Vigor) + " VIG"
+		}
+	case game.EventSynergyTriggered:
+		var gains []string
+		if ev.Defense != 0 {
+			gains = append(gains, signed(ev.Defense)+" DEF")
+		}
+		if ev.Energy != 0 {
+			gains = append(gains, signed(ev.Energy)+" ENE")
+		}
+		return ev.Player + "'s " + ev.Hybrid + " SYNERGY — " + ev.Skill + ": " + strings.Join(gains, ", ")
+	case game.EventHybridDefeated:
+		return ev.Player + "'s " + ev.Hybrid + " is defeated!"
+	case game.EventSwitchedOut:
+		return ev.Player + " SWITCH: " + ev.Hybrid + " withdraws to the reserve"
+	case game.EventReserveEntered:
+		return ev.Player + "'s " + ev.Hybrid + " enters the arena"
+	case game.EventSuddenDeath:
+		return "SUDDEN DEATH: the last hybrids fell together and return with 1 HP"
+	case game.EventFatigueApplied:
+		return "Battle fatigue: " + ev.Player + "'s " + ev.Hybrid + " loses " + itoa(ev.Defense) + " DEF"
+	}
+	return ""
+}
+
+// describeDamage renders the two-line calculation + outcome text for a
+// damage_dealt event.
+func describeDamage(ev game.RoundEvent) string {
+	d := ev.Damage
+	if d == nil {
+		return ev.Target + "'s " + ev.TargetHybrid + " takes damage"
+	}
+	itoa := strconv.Itoa
+	ignored := d.HasModifier(game.DefenseSourceIgnoreDefense)
+	partial := ignored && d.IgnoredPercent > 0 && d.IgnoredPercent < 100
+	halved := d.HasMultiplier(game.DamageSourceZeroVigor)
+	vuln := d.HasMultiplier(game.DamageSourceVulnerable)
+	crit := d.HasMultiplier(game.DamageSourceCritical)
+
+	label := " BASIC ATTACK"
+	if ev.Action == game.PendingActionAbility {
+		label = " ABILITY — " + ev.Skill
+	}
+	calc := ev.Player + label + " — Calculation: Attack " + itoa(d.Attack)
+	if d.AgilityBonus > 0 {
+		calc += " (+" + itoa(d.AgilityBonus) + " from Agility)"
+	}
+	calc += ", Defense " + itoa(d.Defense)
+	if partial {
+		calc += " (" + itoa(d.IgnoredPercent) + "% ignored: -" + itoa(d.DefenseIgnored) + ")"
+	} else if ignored {
+		calc += " (defense ignored)"
+	}
+	calc += "; base damage " + itoa(d.Base)
+	if crit {
+		calc += "; CRITICAL HIT x" + formatFactor(d.Multiplier(game.DamageSourceCritical))
+	}
+	if f := d.Multiplier(game.DamageSourceZeroVigor); halved && f != 0.5 {
+		calc += " (x" + formatFactor(f) + " due to 0 VIG)"
+	} else if halved {
+		calc += " (halved due to 0 VIG)"
+	}
+	if f := d.Multiplier(game.DamageSourceRestingTarget); f != 0 {
+		calc += "; +" + itoa(percentOf(f-1)) + "% vs resting target"
+	}
+	if vuln {
+		calc += "; " + vulnText(d)
+	}
+	if f := d.Multiplier(game.DamageSourceConditional); f != 0 {
+		calc += "; +" + itoa(percentOf(f-1)) + "% conditional bonus"
+	}
+	if f := d.Multiplier(game.DamageSourceDefendPassive); f != 0 {
+		calc += "; -" + itoa(percentOf(1-f)) + "% guarded"
+	}
+	calc += "; final damage " + itoa(d.Final)
+
+	ctxParts := []string{}
+	if d.HasModifier(game.DefenseSourceDefendStance) {
+		ctxParts = append(ctxParts, "defend bonus")
+	}
+	if d.HasModifier(game.DefenseSourceDefenseBuff) {
+		ctxParts = append(ctxParts, "Iron Shell")
+	}
+	if partial {
+		ctxParts = append(ctxParts, "ignored "+itoa(d.IgnoredPercent)+"% defense")
+	} else if ignored {
+		ctxParts = append(ctxParts, "ignored defense")
+	}
+	if crit {
+		ctxParts = append(ctxParts, "critical hit")
+	}
+	if vuln {
+		ctxParts = append(ctxParts, vulnText(d))
+	}
+	ctx := ""
+	if len(ctxParts) > 0 {
+		ctx = " (" + strings.Join(ctxParts, ", ") + ")"
+	}
+	return calc + "\n" + ev.Target + "'s " + ev.TargetHybrid + " takes " + itoa(d.Final) + " damage" + ctx
+}
+
+// describeCondition renders the condition of a conditional skill effect.
+func describeCondition(c game.SkillCondition) string {
+	var parts []string
+	switch c.OpponentAction {
+	case "":
+	case game.ConditionOpponentAttacks:
+		parts = append(parts, "opponent attacks")
+	default:
+		parts = append(parts, "opponent chose "+strings.ReplaceAll(c.OpponentAction, "_", " "))
+	}
+	if c.SelfHPBelowPercent > 0 {
+		parts = append(parts, "HP below "+strconv.Itoa(c.SelfHPBelowPercent)+"%")
+	}
+	if c.OpponentHPBelowPercent > 0 {
+		parts = append(parts, "opponent HP below "+strconv.Itoa(c.OpponentHPBelowPercent)+"%")
+	}
+	if len(parts) == 0 {
+		return "always"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// vulnText describes the Vulnerable damage multiplier of d.
+func vulnText(d *game.DamageBreakdown) string {
+	return "+" + strconv.Itoa(percentOf(d.Multiplier(game.DamageSourceVulnerable)-1)) + "% vs Vulnerable"
+}
+
+// signed renders n with an explicit sign.
+func signed(n int) string {
+	if n > 0 {
+		return "+" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// formatFactor renders a damage multiplier without trailing zeros.
+func formatFactor(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// percentOr returns p, or def for events recorded before the percentage
+// was included.
+func percentOr(p, def int) int {
+	if p == 0 {
+		return def
+	}
+	return p
+}
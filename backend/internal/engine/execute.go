@@ -2,39 +2,38 @@ package engine
 
 import "github.com/ericogr/chimera-cards/internal/game"
 
-// executePlans runs the prepared plans in order and records results in the context.
+// executePlans runs the prepared plans in order and records results in the
+// context. Plans of actors with the same agility form a band whose attacks
+// land together: a hybrid knocked out inside its band still attacks, and
+// the knockouts of the band are applied once all of them did.
 func (rc *roundContext) executePlans(plans []plannedAction) {
-	opponentOf := func(p *game.Player) *game.Player {
-		if p == &rc.g.Players[0] {
-			return &rc.g.Players[1]
+	for start := 0; start < len(plans); {
+		end := start + 1
+		for end < len(plans) && plans[end].agility == plans[start].agility {
+			end++
 		}
-		return &rc.g.Players[0]
-	}
-
-	for _, plan := range plans {
-		if plan.actor.IsDefeated || plan.target.IsDefeated {
-			continue
-		}
-		if plan.actor.CannotAttackUntilRound >= rc.g.RoundCount {
-			continue
-		}
-		switch plan.action {
-		case ActionBasicAttack:
-			rc.execBasicAttack(&plan, opponentOf(plan.player))
-		default:
-			// All ability effects are applied as pre-effects; no separate
-			// execution action is required.
-		}
-
-		if plan.target.CurrentHitPoints <= 0 && !plan.target.IsDefeated {
-			plan.target.IsDefeated = true
-			plan.target.IsActive = false
-			rc.add(opponentOf(plan.player).PlayerName + "'s " + hybridDisplayName(plan.target) + " is defeated!")
+		band := plans[start:end]
+		for i := range band {
+			plan := &band[i]
+			if plan.actor.IsDefeated || plan.target.IsDefeated || plan.target.CurrentHitPoints <= 0 {
+				continue
+			}
+			if plan.actor.HasStatus(game.StatusCannotAttack, rc.g.RoundCount) {
+				continue
+			}
+			switch plan.action {
+			case ActionBasicAttack, ActionAbility:
+				// Ability plans only exist for striking abilities; their other
+				// effects were already applied as pre-effects.
+				rc.execAttack(plan, plan.targetPlayer)
+			}
 		}
-		if plan.actor.CurrentHitPoints <= 0 && !plan.actor.IsDefeated {
-			plan.actor.IsDefeated = true
-			plan.actor.IsActive = false
-			rc.add(plan.player.PlayerName + "'s " + hybridDisplayName(plan.actor) + " is defeated!")
+		// Thorns and reflect can knock out an attacker in the same exchange
+		// as its target; both fall and neither acts again.
+		for i := range band {
+			rc.defeat(band[i].targetPlayer, band[i].target)
+			rc.defeat(band[i].player, band[i].actor)
 		}
+		start = end
 	}
 }
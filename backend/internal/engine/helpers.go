@@ -2,8 +2,9 @@ package engine
 
 import "github.com/ericogr/chimera-cards/internal/game"
 
-// findActiveHybrid returns the active, non-defeated hybrid for a player.
-func findActiveHybrid(p *game.Player) *game.Hybrid {
+// ActiveHybrid returns the player's hybrid in the arena: the active,
+// non-defeated one, or nil.
+func ActiveHybrid(p *game.Player) *game.Hybrid {
 	for i := range p.Hybrids {
 		if p.Hybrids[i].IsActive && !p.Hybrids[i].IsDefeated {
 			return &p.Hybrids[i]
@@ -12,6 +13,11 @@ func findActiveHybrid(p *game.Player) *game.Hybrid {
 	return nil
 }
 
+// IsStunned reports whether the hybrid skips its action in the given round.
+func IsStunned(h *game.Hybrid, round int) bool {
+	return h != nil && h.HasStatus(game.StatusStun, round)
+}
+
 // getChosen returns the entity object referenced by pid inside a hybrid.
 func getChosen(h *game.Hybrid, pid *uint) *game.Entity {
 	if pid == nil {
@@ -1,12 +1,24 @@
 package engine
 
-import "github.com/ericogr/chimera-cards/internal/game"
+import (
+	"math"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
 
 // --- Modifier helpers --------------------------------------------------
+// Percentage modifiers from stacked effects are summed; defense
+// multipliers multiply.
+
+// percentOf converts a ruleset factor (e.g. 0.25) to a whole percentage.
+func percentOf(f float64) int {
+	return int(math.Round(f * 100))
+}
+
 func agilityWithModifiers(h *game.Hybrid, round int) int {
 	agi := h.CurrentAgility
-	if h.AgilityDebuffPercent > 0 && h.AgilityDebuffUntilRound >= round {
-		agi = int(float64(agi) * (1.0 - float64(h.AgilityDebuffPercent)/100.0))
+	if pct := effectTotal(h, game.StatusAgilityDebuff, round); pct > 0 {
+		agi = int(float64(agi) * (1.0 - float64(pct)/100.0))
 	}
 	if agi < 0 {
 		agi = 0
@@ -14,13 +26,15 @@ func agilityWithModifiers(h *game.Hybrid, round int) int {
 	return agi
 }
 
-func defenseWithModifiers(h *game.Hybrid) int {
+func defenseWithModifiers(h *game.Hybrid, round int, r game.Rules) int {
 	d := h.CurrentDefense
-	if h.DefenseBuffMultiplier > 0 && h.DefenseBuffUntilRound > 0 {
-		d = d * h.DefenseBuffMultiplier
+	for _, e := range h.ActiveEffects(game.StatusDefenseMultiplier, round) {
+		if e.Magnitude > 0 {
+			d = d * e.Magnitude
+		}
 	}
 	if h.DefendStanceActive {
-		d = int(float64(d) * 1.5)
+		d = int(float64(d) * r.DefendMultiplier)
 	}
 	if d < 0 {
 		d = 0
@@ -30,14 +44,63 @@ func defenseWithModifiers(h *game.Hybrid) int {
 
 func attackWithModifiers(h *game.Hybrid, round int) int {
 	a := h.CurrentAttack
-	if h.AttackDebuffPercent > 0 && h.AttackDebuffUntilRound >= round {
-		a = int(float64(a) * (1.0 - float64(h.AttackDebuffPercent)/100.0))
+	if pct := effectTotal(h, game.StatusAttackDebuff, round); pct > 0 {
+		a = int(float64(a) * (1.0 - float64(pct)/100.0))
 	}
-	if h.AttackBuffPercent > 0 && h.AttackBuffUntilRound >= round {
-		a = int(float64(a) * (1.0 + float64(h.AttackBuffPercent)/100.0))
+	if pct := effectTotal(h, game.StatusAttackBuff, round); pct > 0 {
+		a = int(float64(a) * (1.0 + float64(pct)/100.0))
 	}
 	if a < 0 {
 		a = 0
 	}
 	return a
 }
+
+// defensePenetration returns the percentage (capped at 100) of the
+// target's Defense ignored by the hybrid's attacks this round.
+func defensePenetration(h *game.Hybrid, round int) int {
+	pen := effectTotal(h, game.StatusIgnoreDefense, round)
+	if pen > 100 {
+		pen = 100
+	}
+	return pen
+}
+
+// agilityDamageBonus returns the Attack gained from agility-scaled damage
+// effects (the smallest divisor wins).
+func agilityDamageBonus(h *game.Hybrid, round int) int {
+	div := 0
+	for _, e := range h.ActiveEffects(game.StatusAgilityDamage, round) {
+		if e.Magnitude > 0 && (div == 0 || e.Magnitude < div) {
+			div = e.Magnitude
+		}
+	}
+	if div == 0 {
+		return 0
+	}
+	return agilityWithModifiers(h, round) / div
+}
+
+// effectTotal sums the magnitudes of the active effects of a kind.
+func effectTotal(h *game.Hybrid, kind game.StatusEffectKind, round int) int {
+	total := 0
+	for _, e := range h.ActiveEffects(kind, round) {
+		total += e.Magnitude
+	}
+	return total
+}
+
+// tickStatusEffects consumes one round from every effect that was active
+// in round and drops the expired ones.
+func tickStatusEffects(h *game.Hybrid, round int) {
+	kept := h.StatusEffects[:0]
+	for _, e := range h.StatusEffects {
+		if e.ActiveIn(round) {
+			e.RemainingRounds--
+		}
+		if e.RemainingRounds > 0 {
+			kept = append(kept, e)
+		}
+	}
+	h.StatusEffects = kept
+}
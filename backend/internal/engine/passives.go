@@ -0,0 +1,56 @@
+package engine
+
+import (
+	"strings"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// passiveTotal sums one trait over the hybrid's passives and returns the
+// names of the passives that contributed to it.
+func passiveTotal(h *game.Hybrid, trait func(game.PassiveEffect) int) (int, string) {
+	total := 0
+	var names []string
+	for _, p := range h.Passives() {
+		if v := trait(p.Effect); v > 0 {
+			total += v
+			names = append(names, p.Name)
+		}
+	}
+	return total, strings.Join(names, ", ")
+}
+
+// applyPassiveRegen restores the extra VIG granted by vigor_regen passives
+// at the start of a round.
+func (rc *roundContext) applyPassiveRegen(p *game.Player, h *game.Hybrid) {
+	regen, names := passiveTotal(h, func(e game.PassiveEffect) int { return e.VigorRegen })
+	if regen <= 0 {
+		return
+	}
+	prev := h.CurrentVIG
+	h.CurrentVIG += regen
+	if h.BaseVIG > 0 && h.CurrentVIG > h.BaseVIG {
+		h.CurrentVIG = h.BaseVIG
+	}
+	if gained := h.CurrentVIG - prev; gained > 0 {
+		ev := rc.event(game.EventPassiveTriggered, p, h)
+		ev.Effect = game.PassiveVigorRegen
+		ev.Skill = names
+		ev.Vigor = gained
+		rc.emit(ev)
+	}
+}
+
+// applyThorns hurts attacker after it damaged the hybrid target.
+func (rc *roundContext) applyThorns(targetPlayer *game.Player, target *game.Hybrid, attackerPlayer *game.Player, attacker *game.Hybrid) {
+	dmg, names := passiveTotal(target, func(e game.PassiveEffect) int { return e.Thorns })
+	if dmg <= 0 {
+		return
+	}
+	attacker.CurrentHitPoints -= dmg
+	ev := rc.withTarget(rc.event(game.EventPassiveTriggered, targetPlayer, target), attackerPlayer, attacker)
+	ev.Effect = game.PassiveThorns
+	ev.Skill = names
+	ev.Amount = dmg
+	rc.emit(ev)
+}
@@ -1,7 +1,6 @@
 package engine
 
 import (
-	"math/rand"
 	"sort"
 
 	"github.com/ericogr/chimera-cards/internal/game"
@@ -9,11 +8,17 @@ import (
 
 // --- Planned action model ---------------------------------------------
 type plannedAction struct {
-	player *game.Player
-	actor  *game.Hybrid
-	target *game.Hybrid
-	action ActionKind
-	entity *game.Entity
+	player       *game.Player
+	actor        *game.Hybrid
+	targetPlayer *game.Player
+	target       *game.Hybrid
+	action       ActionKind
+	entity       *game.Entity
+	// agility is the actor's agility (status modifiers included) when the
+	// plans are ordered.
+	agility int
+	// tiebreak orders plans whose actors have equal agility.
+	tiebreak int64
 }
 
 type ActionKind string
@@ -38,32 +43,38 @@ const (
 	ActionSkillReveal      ActionKind = "skill:reveal"
 )
 
-// buildPlans converts player pending actions into executable plannedAction list.
-func (rc *roundContext) buildPlans(p1, p2 *game.Player, h1, h2 *game.Hybrid) []plannedAction {
-	plans := make([]plannedAction, 0, 4)
+// buildPlans converts the combatants' pending actions into an executable
+// plannedAction list aimed at their locked targets.
+func (rc *roundContext) buildPlans(cs []combatant) []plannedAction {
+	plans := make([]plannedAction, 0, 2*len(cs))
 
-	mapPlan := func(player *game.Player, self, opp *game.Hybrid) {
+	for _, c := range cs {
+		player, self := c.player, c.hybrid
+		opp := rc.targets[player]
 		switch player.PendingActionType {
 		case game.PendingActionBasicAttack:
-			plans = append(plans, plannedAction{player: player, actor: self, target: opp, action: ActionBasicAttack})
-		// Abilities are applied during pre-effect resolution (applyAbilityPreEffects)
-		// and do not create separate execution plans anymore.
+			plans = append(plans, plannedAction{player: player, actor: self, targetPlayer: opp.player, target: opp.hybrid, action: ActionBasicAttack})
+		// Ability effects are applied during pre-effect resolution
+		// (applyAbilityPreEffects); only striking abilities also attack.
 		case game.PendingActionAbility:
-			// no execution plan; pre-effects already applied
+			if ch := getChosen(self, player.PendingActionEntityID); ch != nil && ch.Skill.Effect.Strike {
+				plans = append(plans, plannedAction{player: player, actor: self, targetPlayer: opp.player, target: opp.hybrid, action: ActionAbility, entity: ch})
+			}
 		}
 	}
 
-	mapPlan(p1, h1, h2)
-	mapPlan(p2, h2, h1)
-
-	// sort by agility (desc), tie -> random
+	// sort by agility (desc), tie -> random. Tiebreak keys are drawn up
+	// front from the round's seeded stream so the comparator stays
+	// consistent and the ordering is reproducible.
+	for i := range plans {
+		plans[i].agility = agilityWithModifiers(plans[i].actor, rc.g.RoundCount)
+		plans[i].tiebreak = rc.rng.Int63()
+	}
 	sort.SliceStable(plans, func(i, j int) bool {
-		ai := agilityWithModifiers(plans[i].actor, rc.g.RoundCount)
-		aj := agilityWithModifiers(plans[j].actor, rc.g.RoundCount)
-		if ai == aj {
-			return rand.Intn(2) == 0
+		if plans[i].agility == plans[j].agility {
+			return plans[i].tiebreak < plans[j].tiebreak
 		}
-		return ai > aj
+		return plans[i].agility > plans[j].agility
 	})
 	return plans
 }
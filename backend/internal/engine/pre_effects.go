@@ -1,8 +1,6 @@
 package engine
 
 import (
-	"strconv"
-
 	"github.com/ericogr/chimera-cards/internal/game"
 )
 
@@ -12,7 +10,6 @@ func (rc *roundContext) applyPreEffects(player *game.Player, self, opp *game.Hyb
 	// reset round flags
 	self.AttackHalvedThisRound = false
 	self.VulnerableThisRound = false
-	self.AttackIgnoresDefenseThisRound = false
 
 	switch player.PendingActionType {
 	case game.PendingActionDefend:
@@ -27,19 +24,20 @@ func (rc *roundContext) applyPreEffects(player *game.Player, self, opp *game.Hyb
 }
 
 func (rc *roundContext) applyDefendPreEffects(player *game.Player, self *game.Hybrid) {
-	prevV := self.CurrentVIG
+	spent := 0
 	if self.CurrentVIG > 0 {
 		self.CurrentVIG -= 1
+		spent = 1
 		self.DefendStanceActive = true
 	} else {
 		self.DefendStanceActive = false
 	}
 	self.LastAction = string(ActionDefend)
-	if prevV > 0 {
-		rc.add(player.PlayerName + " DEFEND: spent 1 VIG (+50% Defense this round)")
-	} else {
-		rc.add(player.PlayerName + " DEFEND: 0 VIG — no defense bonus")
-	}
+	ev := rc.event(game.EventCostPaid, player, self)
+	ev.Action = game.PendingActionDefend
+	ev.Vigor = spent
+	ev.Percent = percentOf(rc.rules.DefendMultiplier - 1)
+	rc.emit(ev)
 }
 
 func (rc *roundContext) applyAbilityPreEffects(player *game.Player, self, opp *game.Hybrid) {
@@ -48,9 +46,11 @@ func (rc *roundContext) applyAbilityPreEffects(player *game.Player, self, opp *g
 		return
 	}
 	prevE := self.CurrentEnergy
-	if self.CurrentEnergy >= ch.Skill.Cost {
-		self.CurrentEnergy -= ch.Skill.Cost
+	energyCost := AbilityCost(self, ch, rc.rules)
+	if self.CurrentEnergy >= energyCost {
+		self.CurrentEnergy -= energyCost
 	}
+	startCooldown(self, ch)
 	vigCost := ch.VigorCost
 	prevV := self.CurrentVIG
 	spentV := 0
@@ -71,39 +71,81 @@ func (rc *roundContext) applyAbilityPreEffects(player *game.Player, self, opp *g
 		self.LastAction = string(ActionAbility)
 	}
 
+	skillEvent := func(t game.RoundEventType) game.RoundEvent {
+		ev := rc.event(t, player, self)
+		ev.Action = game.PendingActionAbility
+		ev.Skill = ch.Skill.Name
+		ev.SkillKey = ch.Skill.Key
+		return ev
+	}
+	cost := skillEvent(game.EventCostPaid)
+	cost.Energy = rc.minInt(prevE, energyCost)
+	cost.Vigor = spentV
+	cost.Vulnerable = self.VulnerableThisRound
+	cost.Duration = ch.Skill.Cooldown
+	if cost.Vulnerable {
+		cost.Percent = percentOf(rc.rules.VulnerableMultiplier - 1)
+	}
+	rc.emit(cost)
+
 	// Opponent attack debuff
 	if eff.OpponentAttackDebuffPercent > 0 {
 		dur := eff.OpponentAttackDebuffDuration
 		if dur <= 0 {
 			dur = 1
 		}
-		opp.AttackDebuffPercent = eff.OpponentAttackDebuffPercent
-		opp.AttackDebuffUntilRound = rc.g.RoundCount + dur - 1
-		rc.add(player.PlayerName + " ABILITY — " + ch.Skill.Name + ": -" + strconv.Itoa(eff.OpponentAttackDebuffPercent) + "% opponent Attack for " + strconv.Itoa(dur) + " round(s). Costs: Energy " + strconv.Itoa(rc.minInt(prevE, ch.Skill.Cost)) + ", Vigor " + strconv.Itoa(spentV) + rc.vulnerableTag(self))
+		rc.applyStatus(opp, ch, game.StatusAttackDebuff, eff.OpponentAttackDebuffPercent, rc.g.RoundCount, dur)
+		ev := rc.withTarget(skillEvent(game.EventDebuffApplied), rc.opponentOf(player), opp)
+		ev.Effect = game.EffectAttackDebuff
+		ev.Percent = eff.OpponentAttackDebuffPercent
+		ev.Duration = dur
+		rc.emit(ev)
+	}
+
+	// The user's own offensive buffs apply to this round's strike; an
+	// ability that does not strike cannot attack this round, so its buffs
+	// start with the next round instead of expiring unused.
+	buffStart := rc.g.RoundCount
+	if !eff.Strike {
+		buffStart++
 	}
 
-	// Self attack buff + optionally ignore defense
+	// Self attack buff
 	if eff.AttackBuffPercent > 0 {
 		dur := eff.AttackBuffDuration
 		if dur <= 0 {
 			dur = 1
 		}
-		self.AttackBuffPercent = eff.AttackBuffPercent
-		self.AttackBuffUntilRound = rc.g.RoundCount + dur - 1
-		if eff.AttackIgnoresDefense {
-			self.AttackIgnoresDefenseThisRound = true
-			if eff.AttackIgnoresDefenseDuration > 0 {
-				self.SelfDefenseIgnoredUntilRound = rc.g.RoundCount + eff.AttackIgnoresDefenseDuration - 1
-			} else {
-				self.SelfDefenseIgnoredUntilRound = rc.g.RoundCount
-			}
+		rc.applyStatus(self, ch, game.StatusAttackBuff, eff.AttackBuffPercent, buffStart, dur)
+		ev := skillEvent(game.EventBuffApplied)
+		ev.Effect = game.EffectAttackBuff
+		ev.Percent = eff.AttackBuffPercent
+		ev.Duration = dur
+		rc.emit(ev)
+	}
+
+	// Full or partial defense penetration
+	if pen := eff.DefensePenetration(); pen > 0 {
+		ignoreDur := 1
+		if eff.AttackIgnoresDefenseDuration > 0 {
+			ignoreDur = eff.AttackIgnoresDefenseDuration
 		}
-		rc.add(player.PlayerName + " ABILITY — " + ch.Skill.Name + ": +" + strconv.Itoa(eff.AttackBuffPercent) + "% Attack" + func() string {
-			if eff.AttackIgnoresDefense {
-				return " and ignores Defense"
-			}
-			return ""
-		}() + ". Costs: Energy " + strconv.Itoa(rc.minInt(prevE, ch.Skill.Cost)) + ", Vigor " + strconv.Itoa(spentV) + rc.vulnerableTag(self))
+		rc.applyStatus(self, ch, game.StatusIgnoreDefense, pen, buffStart, ignoreDur)
+		ev := skillEvent(game.EventBuffApplied)
+		ev.Effect = game.EffectIgnoreDefense
+		ev.Percent = pen
+		ev.Duration = ignoreDur
+		rc.emit(ev)
+	}
+
+	// Agility-scaled damage bonus for the user's attacks
+	if eff.AgilityDamageDivisor > 0 {
+		rc.applyStatus(self, ch, game.StatusAgilityDamage, eff.AgilityDamageDivisor, buffStart, 1)
+		ev := skillEvent(game.EventBuffApplied)
+		ev.Effect = game.EffectAgilityDamage
+		ev.Divisor = eff.AgilityDamageDivisor
+		ev.Duration = 1
+		rc.emit(ev)
 	}
 
 	// Defense buff / cannot attack
@@ -112,23 +154,65 @@ func (rc *roundContext) applyAbilityPreEffects(player *game.Player, self, opp *g
 		if dur <= 0 {
 			dur = 1
 		}
-		self.DefenseBuffMultiplier = eff.DefenseBuffMultiplier
-		self.DefenseBuffUntilRound = rc.g.RoundCount + dur - 1
+		rc.applyStatus(self, ch, game.StatusDefenseMultiplier, eff.DefenseBuffMultiplier, rc.g.RoundCount, dur)
+		ev := skillEvent(game.EventBuffApplied)
+		ev.Effect = game.EffectDefenseMultiplier
+		ev.Multiplier = eff.DefenseBuffMultiplier
+		ev.Duration = dur
+		rc.emit(ev)
 		if eff.CannotAttack {
-			self.CannotAttackUntilRound = rc.g.RoundCount + eff.CannotAttackDuration - 1
-		}
-		rc.add(player.PlayerName + " ABILITY — " + ch.Skill.Name + ": Defense x" + strconv.Itoa(eff.DefenseBuffMultiplier) + " for " + strconv.Itoa(dur) + " round(s)" + func() string {
-			if eff.CannotAttack {
-				return " (cannot attack)"
+			cdur := eff.CannotAttackDuration
+			if cdur <= 0 {
+				cdur = 1
 			}
-			return ""
-		}() + ". Costs: Energy " + strconv.Itoa(rc.minInt(prevE, ch.Skill.Cost)) + ", Vigor " + strconv.Itoa(spentV) + rc.vulnerableTag(self))
+			rc.applyStatus(self, ch, game.StatusCannotAttack, 0, rc.g.RoundCount, cdur)
+			ev := skillEvent(game.EventDebuffApplied)
+			ev.Effect = game.EffectCannotAttack
+			ev.Duration = cdur
+			rc.emit(ev)
+		}
 	}
 
 	// Restore energy
 	if eff.RestoreEnergy > 0 {
 		self.CurrentEnergy += eff.RestoreEnergy
-		rc.add(player.PlayerName + " ABILITY — " + ch.Skill.Name + ": +" + strconv.Itoa(eff.RestoreEnergy) + " Energy. Costs: Energy " + strconv.Itoa(rc.minInt(prevE, ch.Skill.Cost)) + ", Vigor " + strconv.Itoa(spentV) + rc.vulnerableTag(self))
+		ev := skillEvent(game.EventEnergyRestored)
+		ev.Energy = eff.RestoreEnergy
+		rc.emit(ev)
+	}
+
+	// Instant heal
+	if eff.Heal > 0 {
+		ev := skillEvent(game.EventHealed)
+		ev.Amount = heal(self, eff.Heal)
+		rc.emit(ev)
+	}
+
+	// Damage over time on the opponent / healing over time on self. Both
+	// tick at the start of the following rounds (see applyUpkeep).
+	if eff.DamageOverTime > 0 {
+		dur := eff.DamageOverTimeDuration
+		if dur <= 0 {
+			dur = 1
+		}
+		rc.applyStatus(opp, ch, game.StatusDamageOverTime, eff.DamageOverTime, rc.g.RoundCount+1, dur)
+		ev := rc.withTarget(skillEvent(game.EventDebuffApplied), rc.opponentOf(player), opp)
+		ev.Effect = game.EffectDamageOverTime
+		ev.Amount = eff.DamageOverTime
+		ev.Duration = dur
+		rc.emit(ev)
+	}
+	if eff.HealOverTime > 0 {
+		dur := eff.HealOverTimeDuration
+		if dur <= 0 {
+			dur = 1
+		}
+		rc.applyStatus(self, ch, game.StatusHealOverTime, eff.HealOverTime, rc.g.RoundCount+1, dur)
+		ev := skillEvent(game.EventBuffApplied)
+		ev.Effect = game.EffectHealOverTime
+		ev.Amount = eff.HealOverTime
+		ev.Duration = dur
+		rc.emit(ev)
 	}
 
 	// Opponent agility debuff
@@ -137,37 +221,109 @@ func (rc *roundContext) applyAbilityPreEffects(player *game.Player, self, opp *g
 		if dur <= 0 {
 			dur = 1
 		}
-		opp.AgilityDebuffPercent = eff.OpponentAgilityDebuffPercent
-		opp.AgilityDebuffUntilRound = rc.g.RoundCount + dur - 1
-		rc.add(player.PlayerName + " ABILITY — " + ch.Skill.Name + ": -" + strconv.Itoa(eff.OpponentAgilityDebuffPercent) + "% opponent Agility for " + strconv.Itoa(dur) + " round(s). Costs: Energy " + strconv.Itoa(rc.minInt(prevE, ch.Skill.Cost)) + ", Vigor " + strconv.Itoa(spentV) + rc.vulnerableTag(self))
+		rc.applyStatus(opp, ch, game.StatusAgilityDebuff, eff.OpponentAgilityDebuffPercent, rc.g.RoundCount, dur)
+		ev := rc.withTarget(skillEvent(game.EventDebuffApplied), rc.opponentOf(player), opp)
+		ev.Effect = game.EffectAgilityDebuff
+		ev.Percent = eff.OpponentAgilityDebuffPercent
+		ev.Duration = dur
+		rc.emit(ev)
+	}
+
+	// Non-striking stuns roll immediately; strikes roll when they land.
+	if eff.StunChance > 0 && !eff.Strike {
+		rc.tryStun(player, self, ch, opp)
 	}
 
+	// Conditional effects read both players' revealed actions.
+	rc.applyConditionalEffects(player, self, opp, ch)
+
 	// Note: priority/reveal mechanics were removed; abilities should use
 	// the remaining structured parameters (buffs/debuffs, restore, etc.).
 }
 
+// tryStun rolls the ability's stun chance against target and, on success,
+// makes it skip its next StunDuration round(s).
+func (rc *roundContext) tryStun(player *game.Player, self *game.Hybrid, ch *game.Entity, target *game.Hybrid) {
+	eff := ch.Skill.Effect
+	if eff.StunChance <= 0 || target.IsDefeated {
+		return
+	}
+	chance := eff.StunChance
+	if eff.StunResistPerAgility > 0 {
+		chance -= agilityWithModifiers(target, rc.g.RoundCount) * eff.StunResistPerAgility
+	}
+	if chance < 0 {
+		chance = 0
+	}
+	if chance > 100 {
+		chance = 100
+	}
+	ev := rc.withTarget(rc.event(game.EventDebuffApplied, player, self), rc.opponentOf(player), target)
+	ev.Action = game.PendingActionAbility
+	ev.Skill = ch.Skill.Name
+	ev.SkillKey = ch.Skill.Key
+	ev.Percent = chance
+	if rc.rng.Intn(100) >= chance {
+		ev.Type = game.EventStunResisted
+		rc.emit(ev)
+		return
+	}
+	dur := eff.StunDuration
+	if dur <= 0 {
+		dur = 1
+	}
+	// The stun covers the following round(s); this round's actions have
+	// already been committed.
+	rc.applyStatus(target, ch, game.StatusStun, 0, rc.g.RoundCount+1, dur)
+	ev.Effect = game.EffectStun
+	ev.Duration = dur
+	rc.emit(ev)
+}
+
+// applyStatus adds a status effect from the ability ch to h, active for
+// dur rounds starting at start.
+func (rc *roundContext) applyStatus(h *game.Hybrid, ch *game.Entity, kind game.StatusEffectKind, magnitude, start, dur int) {
+	h.AddStatusEffect(game.StatusEffect{
+		Kind:            kind,
+		Magnitude:       magnitude,
+		SourceSkill:     ch.Skill.Key,
+		SourceName:      ch.Skill.Name,
+		StartRound:      start,
+		RemainingRounds: dur,
+		Stacking:        ch.Skill.Effect.Stacking,
+	})
+}
+
 func (rc *roundContext) applyBasicAttackPreEffects(player *game.Player, self *game.Hybrid) {
-	prevV := self.CurrentVIG
+	spent := 0
 	if self.CurrentVIG > 0 {
 		self.CurrentVIG -= 1
+		spent = 1
 		self.AttackHalvedThisRound = false
 	} else {
 		self.AttackHalvedThisRound = true
 	}
 	self.LastAction = string(ActionBasicAttack)
-	if prevV > 0 {
-		rc.add(player.PlayerName + " BASIC ATTACK: spent 1 VIG")
-	} else {
-		rc.add(player.PlayerName + " BASIC ATTACK: 0 VIG — damage will be halved")
+	ev := rc.event(game.EventCostPaid, player, self)
+	ev.Action = game.PendingActionBasicAttack
+	ev.Vigor = spent
+	if self.AttackHalvedThisRound {
+		ev.Percent = percentOf(rc.rules.ZeroVigorMultiplier)
 	}
+	rc.emit(ev)
 }
 
 func (rc *roundContext) applyRestPreEffects(player *game.Player, self *game.Hybrid) {
-	self.CurrentVIG += 2
+	prevV := self.CurrentVIG
+	self.CurrentVIG += rc.rules.RestVigor
 	if self.BaseVIG > 0 && self.CurrentVIG > self.BaseVIG {
 		self.CurrentVIG = self.BaseVIG
 	}
-	self.CurrentEnergy += 2
+	self.CurrentEnergy += rc.rules.RestEnergy
 	self.LastAction = string(ActionRest)
-	rc.add(player.PlayerName + " REST: +2 VIG, +2 ENE (VIG capped at base)")
+	ev := rc.event(game.EventRested, player, self)
+	ev.Action = game.PendingActionRest
+	ev.Vigor = self.CurrentVIG - prevV
+	ev.Energy = rc.rules.RestEnergy
+	rc.emit(ev)
 }
@@ -0,0 +1,37 @@
+package engine
+
+import "math/rand"
+
+// NewSeed returns a fresh seed suitable for Game.RNGSeed. It is drawn from
+// the process-wide generator, which Go seeds randomly at startup.
+func NewSeed() int64 {
+	for {
+		if s := rand.Int63(); s != 0 {
+			return s
+		}
+	}
+}
+
+// RandomSource is the randomness drawn while resolving a round (tie-breaks,
+// stun, critical hit and dodge rolls). *rand.Rand satisfies it.
+type RandomSource interface {
+	Intn(n int) int
+	Int63() int64
+}
+
+// roundRand derives the random stream used to resolve a single round from
+// the game's persisted seed and the round number. Each round gets its own
+// independent stream so re-running round N from stored state reproduces it
+// exactly without replaying rounds 1..N-1.
+func roundRand(seed int64, round int) *rand.Rand {
+	return rand.New(rand.NewSource(int64(splitmix64(uint64(seed) ^ splitmix64(uint64(round))))))
+}
+
+// splitmix64 is a small bit mixer used to decorrelate nearby seeds (for
+// example consecutive round numbers) before feeding them to math/rand.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -1,142 +1,282 @@
 package engine
 
-import "github.com/ericogr/chimera-cards/internal/game"
+import (
+	"strings"
 
-// bringReserve promotes the next non-defeated hybrid to active if needed.
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// bringReserve promotes a reserve to active when the player's active
+// hybrid fell: the one chosen in NextReserveIndex when it is still
+// valid, otherwise the first one available.
 func (rc *roundContext) bringReserve(p *game.Player) {
-	if len(p.Hybrids) < 2 {
+	if ActiveHybrid(p) != nil {
 		return
 	}
-	active := false
-	for i := range p.Hybrids {
-		if p.Hybrids[i].IsActive && !p.Hybrids[i].IsDefeated {
-			active = true
-		}
+	next := SwitchTarget(p, p.NextReserveIndex)
+	if next == nil {
+		next = SwitchTarget(p, nil)
 	}
-	if !active {
-		for i := range p.Hybrids {
-			if !p.Hybrids[i].IsDefeated && !p.Hybrids[i].IsActive {
-				h := &p.Hybrids[i]
-				h.IsActive = true
-				h.CurrentHitPoints = h.BaseHitPoints
-				h.CurrentAttack = h.BaseAttack
-				h.CurrentDefense = h.BaseDefense
-				h.CurrentAgility = h.BaseAgility
-				h.CurrentEnergy = h.BaseEnergy
-				break
-			}
-		}
+	if next != nil {
+		p.NextReserveIndex = nil
+		rc.enterArena(p, next)
 	}
 }
 
 // finalizeRound evaluates victory conditions and prepares next round or resolves the match.
 func (rc *roundContext) finalizeRound() {
-	p1 := &rc.g.Players[0]
-	p2 := &rc.g.Players[1]
-	cntDefeated := func(p *game.Player) int {
-		d := 0
-		for i := range p.Hybrids {
-			if p.Hybrids[i].IsDefeated {
-				d++
-			}
+	var standing []int
+	for t := 0; t < game.TeamCount; t++ {
+		if !rc.g.TeamDefeated(t) {
+			standing = append(standing, t)
 		}
-		return d
 	}
-
-	if cntDefeated(p1) == 2 {
-		rc.g.Status = game.StatusFinished
-		rc.g.Winner = p2.PlayerName
-		rc.g.Message = "Victory for player " + p2.PlayerName
-	} else if cntDefeated(p2) == 2 {
-		rc.g.Status = game.StatusFinished
-		rc.g.Winner = p1.PlayerName
-		rc.g.Message = "Victory for player " + p1.PlayerName
+	switch len(standing) {
+	case 0:
+		rc.breakTie()
+	case 1:
+		rc.declareWinner(standing[0])
+	default:
+		if rc.g.SuddenDeath {
+			rc.declareDraw("Draw: both sides survived the sudden-death round")
+		}
 	}
 
 	// next round or resolved
-	rc.g.LastRoundSummary = rc.joinSummary()
 	if rc.g.Status == game.StatusInProgress {
 		rc.g.RoundCount++
 		rc.g.TurnNumber = 1
 		for i := range rc.g.Players {
+			// Effects tick on every hybrid (reserves keep what was applied
+			// to them while active) before the new round begins.
+			for j := range rc.g.Players[i].Hybrids {
+				tickStatusEffects(&rc.g.Players[i].Hybrids[j], rc.g.RoundCount-1)
+			}
 			rc.g.Players[i].HasSubmittedAction = false
 			rc.g.Players[i].PendingActionType = game.PendingActionNone
 			rc.g.Players[i].PendingActionEntityID = nil
+			rc.g.Players[i].PendingSwitchIndex = nil
+			rc.g.Players[i].PendingTargetIndex = nil
 			for j := range rc.g.Players[i].Hybrids {
 				if rc.g.Players[i].Hybrids[j].IsActive && !rc.g.Players[i].Hybrids[j].IsDefeated {
-					// +1 ENE at round start
-					rc.g.Players[i].Hybrids[j].CurrentEnergy += 1
-					// Fatigue schedule: Round 3:-1, Round 4:-2, Round 5+:-3
-					if rc.g.RoundCount >= 3 {
-						dec := 1
-						if rc.g.RoundCount >= 5 {
-							dec = 3
-						} else if rc.g.RoundCount >= 4 {
-							dec = 2
-						}
+					// Energy regeneration at round start
+					rc.g.Players[i].Hybrids[j].CurrentEnergy += rc.rules.EnergyPerRound
+					rc.applyPassiveRegen(&rc.g.Players[i], &rc.g.Players[i].Hybrids[j])
+					rc.applySynergyRegen(&rc.g.Players[i], &rc.g.Players[i].Hybrids[j])
+					// Battle fatigue (see game.Rules.FatigueLoss)
+					if dec := rc.rules.FatigueLoss(rc.g.RoundCount); dec > 0 {
+						prevDef := rc.g.Players[i].Hybrids[j].CurrentDefense
 						rc.g.Players[i].Hybrids[j].CurrentDefense -= dec
 						if rc.g.Players[i].Hybrids[j].CurrentDefense < 0 {
 							rc.g.Players[i].Hybrids[j].CurrentDefense = 0
 						}
+						if lost := prevDef - rc.g.Players[i].Hybrids[j].CurrentDefense; lost > 0 {
+							ev := rc.event(game.EventFatigueApplied, &rc.g.Players[i], &rc.g.Players[i].Hybrids[j])
+							ev.Defense = lost
+							rc.emit(ev)
+						}
 					}
 					rc.g.Players[i].Hybrids[j].DefendStanceActive = false
-					rc.g.Players[i].Hybrids[j].DefenseBuffMultiplier = 0
-					rc.g.Players[i].Hybrids[j].DefenseBuffUntilRound = 0
-					rc.g.Players[i].Hybrids[j].AttackBuffPercent = 0
-					rc.g.Players[i].Hybrids[j].AttackBuffUntilRound = 0
-					rc.g.Players[i].Hybrids[j].AttackDebuffPercent = 0
-					rc.g.Players[i].Hybrids[j].AttackDebuffUntilRound = 0
 					rc.g.Players[i].Hybrids[j].AttackHalvedThisRound = false
 					rc.g.Players[i].Hybrids[j].VulnerableThisRound = false
-					rc.g.Players[i].Hybrids[j].AttackIgnoresDefenseThisRound = false
 				}
 			}
 		}
+		// Stunned hybrids have nothing to choose: their action is
+		// submitted as a skip so the round resolves as soon as the
+		// others act. If everyone is stunned, players still submit (and
+		// their actions are skipped) so the round can be triggered.
+		// Players whose hybrids have all fallen sit out the rest of a
+		// team match the same way.
+		stunned := make([]bool, len(rc.g.Players))
+		cntStunned, inPlay := 0, 0
+		for i := range rc.g.Players {
+			h := ActiveHybrid(&rc.g.Players[i])
+			if h != nil {
+				inPlay++
+			}
+			if IsStunned(h, rc.g.RoundCount) {
+				stunned[i] = true
+				cntStunned++
+			}
+		}
+		for i := range rc.g.Players {
+			if (stunned[i] && cntStunned < inPlay) || ActiveHybrid(&rc.g.Players[i]) == nil {
+				rc.g.Players[i].HasSubmittedAction = true
+				rc.g.Players[i].PendingActionType = game.PendingActionSkip
+			}
+		}
 		rc.g.Phase = game.PhasePlanning
 		rc.g.Message = "New round. Choose your actions."
+		if rc.g.SuddenDeath {
+			rc.g.Message = "Sudden death! The last hybrids are back with 1 HP: this round decides the match."
+		}
 	} else {
 		rc.g.Phase = game.PhaseResolved
 	}
+	rc.publish()
+}
+
+// declareWinner finishes the match in favour of team t.
+func (rc *roundContext) declareWinner(t int) {
+	var names []string
+	for _, i := range rc.g.TeamPlayers(t) {
+		names = append(names, rc.g.Players[i].PlayerName)
+	}
+	winner := strings.Join(names, " & ")
+	rc.g.Status = game.StatusFinished
+	rc.g.Winner = winner
+	rc.g.WinningTeam = &t
+	if len(names) > 1 {
+		rc.g.Message = "Victory for team " + winner
+	} else {
+		rc.g.Message = "Victory for player " + winner
+	}
+}
+
+// breakTie settles a round in which the last hybrids of every side fell
+// together, following the Tiebreak rule. A knockout in the sudden-death
+// round itself is a draw.
+func (rc *roundContext) breakTie() {
+	if rc.g.SuddenDeath {
+		rc.declareDraw(drawMessage)
+		return
+	}
+	switch rc.rules.Tiebreak {
+	case game.TiebreakRemainingHP:
+		rc.declareLeader(func(h *game.Hybrid) int { return rc.startHP[h] })
+	case game.TiebreakAgility:
+		rc.declareLeader(func(h *game.Hybrid) int { return agilityWithModifiers(h, rc.g.RoundCount) })
+	case game.TiebreakSuddenDeath:
+		rc.startSuddenDeath()
+	default:
+		rc.declareDraw(drawMessage)
+	}
+}
+
+// declareLeader gives the match to the side whose hybrids that fell this
+// round add up to the highest stat; even sides draw.
+func (rc *roundContext) declareLeader(stat func(h *game.Hybrid) int) {
+	score := make([]int, game.TeamCount)
+	for _, f := range rc.fallen {
+		score[rc.g.TeamOf(rc.playerIndex(f.player))] += stat(f.hybrid)
+	}
+	best, tied := 0, false
+	for t := 1; t < game.TeamCount; t++ {
+		switch {
+		case score[t] > score[best]:
+			best, tied = t, false
+		case score[t] == score[best]:
+			tied = true
+		}
+	}
+	if tied {
+		rc.declareDraw(drawMessage)
+		return
+	}
+	rc.declareWinner(best)
+}
+
+// startSuddenDeath brings the hybrids that fell this round back into the
+// arena with 1 HP and no status effects for one more round.
+func (rc *roundContext) startSuddenDeath() {
+	rc.g.SuddenDeath = true
+	for _, f := range rc.fallen {
+		f.hybrid.IsDefeated = false
+		f.hybrid.IsActive = true
+		f.hybrid.CurrentHitPoints = 1
+		f.hybrid.StatusEffects = nil
+	}
+	rc.emit(game.RoundEvent{Type: game.EventSuddenDeath, PlayerIndex: -1})
+}
+
+const drawMessage = "Draw: the last hybrids of every side fell together"
+
+// declareDraw finishes the match without a winner.
+func (rc *roundContext) declareDraw(message string) {
+	rc.g.Status = game.StatusFinished
+	rc.g.Winner = ""
+	rc.g.WinningTeam = nil
+	rc.g.Draw = true
+	rc.g.Message = message
 }
 
 // ResolveRound is the main entry point for resolving a round. It orchestrates
 // pre-effects, execution of planned actions and round finalization.
 func ResolveRound(g *game.Game) {
-	if len(g.Players) != 2 {
+	ResolveRoundWithRules(g, RulesFor(g))
+}
+
+// ResolveRoundWithRules resolves a round like ResolveRound using the given
+// ruleset instead of the configured one. Every player with a hybrid in the
+// arena acts; attacks resolve in agility order across all of them.
+func ResolveRoundWithRules(g *game.Game, r game.Rules) {
+	ResolveRoundWithSource(g, r, roundRand(g.RNGSeed, g.RoundCount))
+}
+
+// ResolveRoundWithSource resolves a round like ResolveRoundWithRules,
+// drawing every random decision from src instead of the stream derived
+// from the game's seed. Tests use it to force specific rolls.
+func ResolveRoundWithSource(g *game.Game, r game.Rules, src RandomSource) {
+	if len(g.Players) < game.TeamCount {
 		return
 	}
 	// begin
 	g.Phase = game.PhaseResolving
-	rc := newRoundContext(g)
+	rc := newRoundContext(g, r, src)
 
-	p1 := &g.Players[0]
-	p2 := &g.Players[1]
-	h1 := findActiveHybrid(p1)
-	h2 := findActiveHybrid(p2)
-	if h1 == nil || h2 == nil {
+	cs := rc.combatants()
+	if !teamsInPlay(g, cs) {
 		return
 	}
 
 	// Stun checks
-	if h1.StunnedUntilRound >= g.RoundCount {
-		p1.PendingActionType = game.PendingActionSkip
-		h1.LastAction = "stunned"
+	for _, c := range cs {
+		if IsStunned(c.hybrid, g.RoundCount) {
+			c.player.PendingActionType = game.PendingActionSkip
+			c.hybrid.LastAction = "stunned"
+			rc.emit(rc.event(game.EventStunned, c.player, c.hybrid))
+		}
+	}
+
+	// Abilities the hybrid cannot afford or that are on cooldown fizzle;
+	// then cooldowns tick so an ability used now is unavailable for the
+	// next Cooldown rounds.
+	for _, c := range cs {
+		rc.checkAbility(c.player, c.hybrid)
+	}
+	for _, c := range cs {
+		tickCooldowns(c.player)
 	}
-	if h2.StunnedUntilRound >= g.RoundCount {
-		p2.PendingActionType = game.PendingActionSkip
-		h2.LastAction = "stunned"
+
+	// Voluntary switches happen first, so the incoming hybrid takes
+	// whatever the opponents do this round.
+	for i := range cs {
+		if rc.applySwitch(cs[i].player) {
+			cs[i].hybrid = ActiveHybrid(cs[i].player)
+		}
 	}
+	rc.lockTargets(cs)
 
-	// Pre-effects and costs
-	rc.applyPreEffects(p1, h1, h2)
-	rc.applyPreEffects(p2, h2, h1)
+	// Upkeep: over-time effects tick before anyone acts
+	for _, c := range cs {
+		rc.applyUpkeep(c.player, c.hybrid)
+	}
+
+	// Pre-effects and costs (a hybrid defeated during upkeep does not act)
+	for _, c := range cs {
+		if !c.hybrid.IsDefeated {
+			rc.applyPreEffects(c.player, c.hybrid, rc.opponentHybrid(c.player))
+		}
+	}
 
 	// Build & execute plans
-	plans := rc.buildPlans(p1, p2, h1, h2)
+	plans := rc.buildPlans(cs)
 	rc.executePlans(plans)
 
 	// Bring reserves and finalize
-	rc.bringReserve(p1)
-	rc.bringReserve(p2)
+	for _, c := range cs {
+		rc.bringReserve(c.player)
+	}
 	rc.finalizeRound()
 }
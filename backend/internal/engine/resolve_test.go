@@ -2,6 +2,7 @@ package engine
 
 import (
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/ericogr/chimera-cards/internal/game"
@@ -30,3 +31,842 @@ func TestResolveRound_BasicAttacks(t *testing.T) {
 		t.Fatalf("expected round to increment, got %d", g.RoundCount)
 	}
 }
+
+func TestResolveRound_SeededTiebreakIsDeterministic(t *testing.T) {
+	newGame := func(seed int64) *game.Game {
+		g := &game.Game{Players: []game.Player{
+			{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 4, CurrentAgility: 4, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+			{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 4, CurrentAgility: 4, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		}}
+		g.Status = game.StatusInProgress
+		g.RoundCount = 1
+		g.RNGSeed = seed
+		g.Players[0].PendingActionType = game.PendingActionBasicAttack
+		g.Players[1].PendingActionType = game.PendingActionBasicAttack
+		return g
+	}
+
+	// Equal agility and lethal attacks: the tie-break orders the attacks
+	// in the log, and both land before either hybrid falls.
+	for seed := int64(1); seed <= 20; seed++ {
+		a := newGame(seed)
+		b := newGame(seed)
+		ResolveRound(a)
+		ResolveRound(b)
+		if a.LastRoundSummary != b.LastRoundSummary {
+			t.Fatalf("seed %d resolved differently:\n%s\n--\n%s", seed, a.LastRoundSummary, b.LastRoundSummary)
+		}
+	}
+
+	firstStrikers := map[int]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		g := newGame(seed)
+		ResolveRound(g)
+		for _, ev := range g.LastRoundEvents {
+			if ev.Type == game.EventDamageDealt {
+				firstStrikers[ev.PlayerIndex] = true
+				break
+			}
+		}
+		if !g.Players[0].Hybrids[0].IsDefeated || !g.Players[1].Hybrids[0].IsDefeated || !g.Draw {
+			t.Fatalf("seed %d: expected both hybrids of the same agility to fall", seed)
+		}
+	}
+	if len(firstStrikers) < 2 {
+		t.Fatalf("expected different seeds to break ties both ways")
+	}
+}
+
+func TestResolveRound_FasterKnockoutPreventsAttack(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{
+			{Name: "Fast", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 3, IsActive: true},
+		}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{
+			{Name: "Slow", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 4, CurrentAgility: 4, BaseVIG: 3, CurrentVIG: 3, IsActive: true},
+			{Name: "Reserve", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAttack: 1, CurrentAttack: 1, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3},
+		}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+	// Fast misses its critical (roll 99) and knocks Slow out before it acts.
+	resolveWithRolls(g, 99)
+
+	if !g.Players[1].Hybrids[0].IsDefeated {
+		t.Fatalf("expected the slower hybrid to fall")
+	}
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 5 {
+		t.Fatalf("expected the knocked out hybrid not to attack, got PV=%d", hp)
+	}
+	for _, ev := range g.LastRoundEvents {
+		if ev.Type == game.EventDamageDealt && ev.PlayerIndex == 1 {
+			t.Fatalf("expected no attack from P2, got %+v", ev)
+		}
+	}
+}
+
+func TestResolveRound_EmitsTypedEvents(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 6, CurrentAttack: 6, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 1, CurrentAttack: 1, BaseDefense: 2, CurrentDefense: 2, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionDefend
+
+	ResolveRound(g)
+
+	var dmg *game.RoundEvent
+	costs := 0
+	for i := range g.LastRoundEvents {
+		ev := &g.LastRoundEvents[i]
+		if ev.Round != 1 {
+			t.Fatalf("expected events tagged with round 1, got %d", ev.Round)
+		}
+		switch ev.Type {
+		case game.EventCostPaid:
+			costs++
+		case game.EventDamageDealt:
+			dmg = ev
+		}
+	}
+	if costs != 2 {
+		t.Fatalf("expected 2 cost_paid events, got %d", costs)
+	}
+	if dmg == nil || dmg.Damage == nil {
+		t.Fatalf("expected a damage_dealt event with a breakdown, got %+v", g.LastRoundEvents)
+	}
+	// DEF 2 * 1.5 (defend stance) = 3; 6 - 3 = 3 damage.
+	if dmg.PlayerIndex != 0 || dmg.TargetIndex == nil || *dmg.TargetIndex != 1 {
+		t.Fatalf("unexpected attacker/target indices: %+v", dmg)
+	}
+	if dmg.Damage.Defense != 3 || dmg.Damage.Final != 3 || !dmg.Damage.HasModifier(game.DefenseSourceDefendStance) {
+		t.Fatalf("unexpected damage breakdown: %+v", dmg.Damage)
+	}
+	if g.LastRoundSummary != SummarizeEvents(g.LastRoundEvents) {
+		t.Fatalf("summary should be derived from events")
+	}
+}
+
+func TestResolveRound_StrikeWithPartialPenetration(t *testing.T) {
+	pounce := game.Entity{Name: "Cheetah", VigorCost: 1, Skill: game.Skill{Name: "Swift Pounce", Cost: 1, Key: "skill:swift_pounce", Effect: game.SkillEffect{
+		Strike:               true,
+		IgnoreDefensePercent: 40,
+		AgilityDamageDivisor: 5,
+	}}}
+	pounce.ID = 7
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{pounce}, BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 8, CurrentAttack: 8, BaseAgility: 10, CurrentAgility: 10, CurrentEnergy: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 20, CurrentHitPoints: 20, BaseAttack: 1, CurrentAttack: 1, BaseDefense: 5, CurrentDefense: 5, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	id := pounce.ID
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &id
+	g.Players[1].PendingActionType = game.PendingActionRest
+
+	ResolveRound(g)
+
+	var dmg *game.RoundEvent
+	for i := range g.LastRoundEvents {
+		if g.LastRoundEvents[i].Type == game.EventDamageDealt {
+			dmg = &g.LastRoundEvents[i]
+		}
+	}
+	if dmg == nil || dmg.Action != game.PendingActionAbility || dmg.Skill != "Swift Pounce" {
+		t.Fatalf("expected the ability to strike, got %+v", g.LastRoundEvents)
+	}
+	// ATK 8 + 10/5 agility bonus = 10; DEF 5 with 40% ignored = 5 - 2 = 3.
+	d := dmg.Damage
+	if d.Attack != 10 || d.AgilityBonus != 2 || d.Defense != 3 || d.DefenseIgnored != 2 || d.IgnoredPercent != 40 || d.Final != 7 {
+		t.Fatalf("unexpected damage breakdown: %+v", d)
+	}
+	if g.Players[1].Hybrids[0].CurrentHitPoints != 13 {
+		t.Fatalf("expected target at 13 PV, got %d", g.Players[1].Hybrids[0].CurrentHitPoints)
+	}
+	if !strings.Contains(g.LastRoundSummary, "Defense 3 (40% ignored: -2)") {
+		t.Fatalf("expected penetration in the damage log, got:\n%s", g.LastRoundSummary)
+	}
+}
+
+func TestResolveRound_StunSkipsNextRound(t *testing.T) {
+	blow := game.Entity{Name: "Gorilla", Skill: game.Skill{Name: "Stunning Blow", Key: "skill:stun", Effect: game.SkillEffect{
+		Strike:     true,
+		StunChance: 100,
+	}}}
+	blow.ID = 9
+	newGame := func(resist int) *game.Game {
+		blow.Skill.Effect.StunResistPerAgility = resist
+		g := &game.Game{Players: []game.Player{
+			{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{blow}, BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 3, CurrentAttack: 3, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+			{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 3, CurrentAttack: 3, BaseAgility: 10, CurrentAgility: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		}}
+		g.Status = game.StatusInProgress
+		g.RoundCount = 1
+		id := blow.ID
+		g.Players[0].PendingActionType = game.PendingActionAbility
+		g.Players[0].PendingActionEntityID = &id
+		g.Players[1].PendingActionType = game.PendingActionBasicAttack
+		return g
+	}
+
+	g := newGame(0)
+	ResolveRound(g)
+	target := &g.Players[1].Hybrids[0]
+	if IsStunned(target, 1) || !IsStunned(target, 2) || target.StatusEffects[0].RemainingRounds != 1 {
+		t.Fatalf("expected a one-round stun starting in round 2, got %+v", target.StatusEffects)
+	}
+	if !g.Players[1].HasSubmittedAction || g.Players[1].PendingActionType != game.PendingActionSkip {
+		t.Fatalf("expected the stunned player's skip to be pre-submitted, got %+v", g.Players[1])
+	}
+	if g.Players[0].HasSubmittedAction {
+		t.Fatalf("the stunning player must still choose an action")
+	}
+
+	g.Players[0].PendingActionType = game.PendingActionRest
+	g.Players[0].HasSubmittedAction = true
+	hp := g.Players[0].Hybrids[0].CurrentHitPoints
+	ResolveRound(g)
+	if g.Players[0].Hybrids[0].CurrentHitPoints != hp {
+		t.Fatalf("stunned hybrid should not have attacked")
+	}
+	found := false
+	for _, ev := range g.LastRoundEvents {
+		if ev.Type == game.EventStunned && ev.PlayerIndex == 1 {
+			found = true
+		}
+	}
+	if !found || g.Players[1].HasSubmittedAction || len(g.Players[1].Hybrids[0].StatusEffects) != 0 {
+		t.Fatalf("expected a stunned event and the stun to expire, got %+v", g.LastRoundEvents)
+	}
+
+	// 10 AGI * 10 points of resistance cancels the 100% chance.
+	g = newGame(10)
+	ResolveRound(g)
+	if len(g.Players[1].Hybrids[0].StatusEffects) != 0 {
+		t.Fatalf("expected the stun to be resisted")
+	}
+	if !strings.Contains(g.LastRoundSummary, "resists the stun") {
+		t.Fatalf("expected the resisted stun in the log, got:\n%s", g.LastRoundSummary)
+	}
+}
+
+func TestResolveRound_MultiRoundEffectsLast(t *testing.T) {
+	ink := game.Entity{Name: "Octopus", Skill: game.Skill{Name: "Ink Curtain", Key: "skill:ink", Effect: game.SkillEffect{
+		OpponentAttackDebuffPercent:  50,
+		OpponentAttackDebuffDuration: 2,
+	}}}
+	ink.ID = 3
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{ink}, BaseHitPoints: 50, CurrentHitPoints: 50, BaseAttack: 1, CurrentAttack: 1, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 50, CurrentHitPoints: 50, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 9, CurrentVIG: 9, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	id := ink.ID
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &id
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+
+	// Round 1: ATK 10 halved by the debuff.
+	ResolveRound(g)
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 45 {
+		t.Fatalf("round 1: expected 45 PV, got %d", hp)
+	}
+	// Round 2: the debuff is still active.
+	g.Players[0].PendingActionType = game.PendingActionRest
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+	ResolveRound(g)
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 40 {
+		t.Fatalf("round 2: expected 40 PV, got %d", hp)
+	}
+	if n := len(g.Players[1].Hybrids[0].StatusEffects); n != 0 {
+		t.Fatalf("expected the debuff to expire after 2 rounds, %d effect(s) left", n)
+	}
+	// Round 3: full damage again.
+	g.Players[0].PendingActionType = game.PendingActionRest
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+	ResolveRound(g)
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 30 {
+		t.Fatalf("round 3: expected 30 PV, got %d", hp)
+	}
+}
+
+func TestResolveRound_NonStrikingBuffStartsNextRound(t *testing.T) {
+	flight := game.Entity{Name: "Eagle", Skill: game.Skill{Name: "Strategic Flight", Key: "skill:flight", Effect: game.SkillEffect{
+		AttackBuffPercent:  50,
+		AttackBuffDuration: 1,
+	}}}
+	flight.ID = 4
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{flight}, BaseHitPoints: 50, CurrentHitPoints: 50, BaseAttack: 10, CurrentAttack: 10, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 9, CurrentVIG: 9, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 50, CurrentHitPoints: 50, BaseAttack: 1, CurrentAttack: 1, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 9, CurrentVIG: 9, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	id := flight.ID
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &id
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+
+	// Round 2: the buff applies to the first attack after the ability.
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if hp := g.Players[1].Hybrids[0].CurrentHitPoints; hp != 35 {
+		t.Fatalf("round 2: expected 35 PV after a buffed attack, got %d", hp)
+	}
+	// Round 3: the buff has expired.
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if hp := g.Players[1].Hybrids[0].CurrentHitPoints; hp != 25 {
+		t.Fatalf("round 3: expected 25 PV, got %d", hp)
+	}
+}
+
+func TestAddStatusEffect_StackingPolicies(t *testing.T) {
+	base := game.StatusEffect{Kind: game.StatusAttackBuff, Magnitude: 20, SourceSkill: "skill:x", StartRound: 1, RemainingRounds: 2}
+
+	h := &game.Hybrid{}
+	h.AddStatusEffect(base)
+	next := base
+	next.Magnitude = 10
+	next.RemainingRounds = 1
+	h.AddStatusEffect(next)
+	if len(h.StatusEffects) != 1 || h.StatusEffects[0].Magnitude != 10 || h.StatusEffects[0].RemainingRounds != 1 {
+		t.Fatalf("refresh: unexpected effects %+v", h.StatusEffects)
+	}
+
+	h = &game.Hybrid{}
+	base.Stacking = game.StackExtend
+	next.Stacking = game.StackExtend
+	h.AddStatusEffect(base)
+	h.AddStatusEffect(next)
+	if len(h.StatusEffects) != 1 || h.StatusEffects[0].Magnitude != 20 || h.StatusEffects[0].RemainingRounds != 3 {
+		t.Fatalf("extend: unexpected effects %+v", h.StatusEffects)
+	}
+
+	h = &game.Hybrid{CurrentAttack: 10}
+	base.Stacking = game.StackIndependent
+	next.Stacking = game.StackIndependent
+	h.AddStatusEffect(base)
+	h.AddStatusEffect(next)
+	if len(h.StatusEffects) != 2 || attackWithModifiers(h, 1) != 13 {
+		t.Fatalf("stack: unexpected effects %+v (attack %d)", h.StatusEffects, attackWithModifiers(h, 1))
+	}
+}
+
+func TestResolveRound_OverTimeEffects(t *testing.T) {
+	venom := game.Entity{Name: "Snake", Skill: game.Skill{Name: "Venom", Key: "skill:venom", Effect: game.SkillEffect{
+		Heal:                   5,
+		DamageOverTime:         3,
+		DamageOverTimeDuration: 2,
+		HealOverTime:           2,
+		HealOverTimeDuration:   2,
+	}}}
+	venom.ID = 4
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{venom}, BaseHitPoints: 10, CurrentHitPoints: 4, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 5, CurrentHitPoints: 5, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	id := venom.ID
+	rest := func() {
+		g.Players[0].PendingActionType = game.PendingActionRest
+		g.Players[1].PendingActionType = game.PendingActionRest
+	}
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &id
+	g.Players[1].PendingActionType = game.PendingActionRest
+
+	// Round 1: instant heal only; over-time effects start next round.
+	ResolveRound(g)
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 9 {
+		t.Fatalf("round 1: expected 9 PV after healing, got %d", hp)
+	}
+	if hp := g.Players[1].Hybrids[0].CurrentHitPoints; hp != 5 {
+		t.Fatalf("round 1: poison should not tick yet, got %d PV", hp)
+	}
+
+	// Round 2: regeneration capped at base HP, poison ticks.
+	rest()
+	ResolveRound(g)
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 10 {
+		t.Fatalf("round 2: expected regeneration capped at 10 PV, got %d", hp)
+	}
+	if hp := g.Players[1].Hybrids[0].CurrentHitPoints; hp != 2 {
+		t.Fatalf("round 2: expected 2 PV after poison, got %d", hp)
+	}
+	if !strings.Contains(g.LastRoundSummary, "takes 3 damage over time (Venom)") {
+		t.Fatalf("expected the poison tick in the log, got:\n%s", g.LastRoundSummary)
+	}
+
+	// Round 3: the second poison tick defeats the target before it acts.
+	rest()
+	ResolveRound(g)
+	if !g.Players[1].Hybrids[0].IsDefeated {
+		t.Fatalf("round 3: expected the poisoned hybrid to be defeated")
+	}
+	for _, ev := range g.LastRoundEvents {
+		if ev.Type == game.EventRested && ev.PlayerIndex == 1 {
+			t.Fatalf("a hybrid defeated during upkeep must not act")
+		}
+	}
+}
+
+func TestResolveRound_VoluntarySwitch(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{
+			{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 7, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 2, IsActive: true, Revealed: true,
+				StatusEffects: []game.StatusEffect{{Kind: game.StatusAttackDebuff, Magnitude: 50, SourceSkill: "skill:roar", StartRound: 1, RemainingRounds: 3}}},
+			{Name: "H1b", BaseHitPoints: 10, BaseDefense: 1, BaseAgility: 1, BaseVIG: 3, CurrentVIG: 3},
+		}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 5, CurrentAttack: 5, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	idx := 1
+	g.Players[0].PendingActionType = game.PendingActionSwitch
+	g.Players[0].PendingSwitchIndex = &idx
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+
+	// Round 1: the incoming hybrid takes the opponent's attack.
+	ResolveRound(g)
+	out, in := &g.Players[0].Hybrids[0], &g.Players[0].Hybrids[1]
+	if out.IsActive || !in.IsActive || !in.Revealed {
+		t.Fatalf("expected H1b to be the active hybrid after the switch")
+	}
+	if out.CurrentHitPoints != 7 || out.CurrentVIG != 2 {
+		t.Fatalf("switched-out hybrid should keep PV/VIG, got PV=%d VIG=%d", out.CurrentHitPoints, out.CurrentVIG)
+	}
+	if len(out.StatusEffects) != 1 || out.StatusEffects[0].RemainingRounds != 2 {
+		t.Fatalf("switched-out hybrid should keep its effects counting down, got %+v", out.StatusEffects)
+	}
+	if in.CurrentHitPoints != 6 {
+		t.Fatalf("expected the incoming hybrid to take 4 damage, got PV=%d", in.CurrentHitPoints)
+	}
+	if g.Players[0].PendingSwitchIndex != nil {
+		t.Fatalf("expected the pending switch to be cleared")
+	}
+	if !strings.Contains(g.LastRoundSummary, "P1 SWITCH: H1 withdraws to the reserve") {
+		t.Fatalf("expected the switch in the log, got:\n%s", g.LastRoundSummary)
+	}
+
+	// Round 2: switching back restores H1 as it left, not from base stats.
+	back := 0
+	g.Players[0].PendingActionType = game.PendingActionSwitch
+	g.Players[0].PendingSwitchIndex = &back
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if !out.IsActive || out.CurrentHitPoints != 7 {
+		t.Fatalf("expected H1 back in the arena with 7 PV, got active=%v PV=%d", out.IsActive, out.CurrentHitPoints)
+	}
+	if in.CurrentHitPoints != 6 {
+		t.Fatalf("expected H1b to keep 6 PV on the bench, got %d", in.CurrentHitPoints)
+	}
+}
+
+func TestResolveRoundWithRules_UsesConfiguredNumbers(t *testing.T) {
+	r := game.DefaultRules()
+	r.EnergyPerRound = 2
+	r.RestVigor = 1
+	r.RestEnergy = 3
+	r.DefendMultiplier = 2
+	r.BaseVigor = 4
+	r.Fatigue = game.FatigueRules{StartRound: 2, DefenseLoss: []int{2}}
+
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 20, BaseAttack: 9, BaseDefense: 1, BaseAgility: 5, BaseEnergy: 1}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 20, BaseAttack: 1, BaseDefense: 3, BaseAgility: 1, BaseEnergy: 1}}},
+	}}
+	PrepareMatchWithRules(g, r)
+	h1, h2 := &g.Players[0].Hybrids[0], &g.Players[1].Hybrids[0]
+	if h1.BaseVIG != 4 || h1.CurrentVIG != 4 || h1.CurrentEnergy != 3 {
+		t.Fatalf("expected VIG 4 and ENE 1+2 after setup, got VIG=%d/%d ENE=%d", h1.CurrentVIG, h1.BaseVIG, h1.CurrentEnergy)
+	}
+
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionDefend
+	ResolveRoundWithRules(g, r)
+	// DEF 3 x2 (defend) = 6; 9 - 6 = 3 damage.
+	if h2.CurrentHitPoints != 17 {
+		t.Fatalf("expected 3 damage through a x2 defense, got PV=%d", h2.CurrentHitPoints)
+	}
+	if !strings.Contains(g.LastRoundSummary, "(+100% Defense this round)") {
+		t.Fatalf("expected the configured defend bonus in the log, got:\n%s", g.LastRoundSummary)
+	}
+	// Round 2 starts: +2 ENE and 2 DEF of fatigue.
+	if h2.CurrentDefense != 1 || h1.CurrentDefense != 0 {
+		t.Fatalf("expected fatigue of 2 DEF from round 2, got DEF %d/%d", h1.CurrentDefense, h2.CurrentDefense)
+	}
+
+	g.Players[0].PendingActionType = game.PendingActionRest
+	g.Players[1].PendingActionType = game.PendingActionRest
+	vig, ene := h2.CurrentVIG, h2.CurrentEnergy
+	ResolveRoundWithRules(g, r)
+	if h2.CurrentVIG != vig+1 || h2.CurrentEnergy != ene+3+2 {
+		t.Fatalf("expected rest +1 VIG/+3 ENE and +2 ENE regen, got VIG %d->%d ENE %d->%d", vig, h2.CurrentVIG, ene, h2.CurrentEnergy)
+	}
+}
+
+// scriptedRolls is a RandomSource returning preset Intn results in order.
+type scriptedRolls struct{ rolls []int }
+
+func (s *scriptedRolls) Intn(n int) int {
+	if len(s.rolls) == 0 {
+		return n - 1
+	}
+	r := s.rolls[0]
+	s.rolls = s.rolls[1:]
+	return r
+}
+
+func (s *scriptedRolls) Int63() int64 { return 0 }
+
+// resolveWithRolls resolves a round of g with the given Intn results.
+func resolveWithRolls(g *game.Game, rolls ...int) {
+	ResolveRoundWithSource(g, RulesFor(g), &scriptedRolls{rolls: rolls})
+}
+
+func TestResolveRound_AgilityCriticalAndDodge(t *testing.T) {
+	newGame := func() *game.Game {
+		g := &game.Game{Players: []game.Player{
+			{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "Fast", BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 7, CurrentAttack: 7, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 9, CurrentAgility: 9, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+			{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "Slow", BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 7, CurrentAttack: 7, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		}}
+		g.Status = game.StatusInProgress
+		g.RoundCount = 1
+		g.Players[0].PendingActionType = game.PendingActionBasicAttack
+		g.Players[1].PendingActionType = game.PendingActionBasicAttack
+		return g
+	}
+
+	// Fast strikes first: a critical roll of 0 (< 40%). Slow's attack is
+	// dodged with a roll of 10 (< 30%).
+	g := newGame()
+	resolveWithRolls(g, 0, 10)
+	if hp := g.Players[1].Hybrids[0].CurrentHitPoints; hp != 21 {
+		t.Fatalf("expected a 6 x1.5 = 9 damage critical hit, got PV=%d", hp)
+	}
+	if hp := g.Players[0].Hybrids[0].CurrentHitPoints; hp != 30 {
+		t.Fatalf("expected the slow hybrid's attack to be dodged, got PV=%d", hp)
+	}
+	for _, want := range []string{"CRITICAL HIT x1.5", "P2 BASIC ATTACK: P1's Fast dodges the attack (30% dodge chance)"} {
+		if !strings.Contains(g.LastRoundSummary, want) {
+			t.Fatalf("expected %q in the log, got:\n%s", want, g.LastRoundSummary)
+		}
+	}
+
+	// Failed rolls: plain damage both ways and chances recorded.
+	g = newGame()
+	resolveWithRolls(g, 99, 99)
+	if g.Players[0].Hybrids[0].CurrentHitPoints != 24 || g.Players[1].Hybrids[0].CurrentHitPoints != 24 {
+		t.Fatalf("expected 6 damage both ways, got %d/%d", g.Players[0].Hybrids[0].CurrentHitPoints, g.Players[1].Hybrids[0].CurrentHitPoints)
+	}
+	for _, ev := range g.LastRoundEvents {
+		if ev.Type == game.EventDamageDealt && ev.PlayerIndex == 0 && (ev.Damage.CriticalChance != 40 || ev.Damage.HasMultiplier(game.DamageSourceCritical)) {
+			t.Fatalf("unexpected critical data: %+v", ev.Damage)
+		}
+	}
+}
+
+func TestResolveRound_PassiveTraits(t *testing.T) {
+	passive := func(name string, eff game.PassiveEffect) game.Entity {
+		return game.Entity{Name: name, Passive: &game.Passive{Name: name + " Trait", Key: "passive:" + name, Effect: eff}}
+	}
+	rhino := passive("Rhino", game.PassiveEffect{Thorns: 2})
+	turtle := passive("Turtle", game.PassiveEffect{DefendDamageReductionPercent: 50})
+	wolf := passive("Wolf", game.PassiveEffect{BonusVsRestingPercent: 50})
+	eagle := passive("Eagle", game.PassiveEffect{VigorRegen: 1})
+
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{wolf, eagle}, BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 9, CurrentAttack: 9, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 3, CurrentAgility: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseEntities: []game.Entity{rhino, turtle}, BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 1, CurrentAttack: 1, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 3, CurrentAgility: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	h1, h2 := &g.Players[0].Hybrids[0], &g.Players[1].Hybrids[0]
+
+	// Round 1: attack into a resting target: (9-1) x1.5 = 12, thorns 2.
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if h2.CurrentHitPoints != 18 {
+		t.Fatalf("expected 12 damage vs a resting target, got PV=%d", h2.CurrentHitPoints)
+	}
+	if h1.CurrentHitPoints != 28 {
+		t.Fatalf("expected 2 thorns damage on the attacker, got PV=%d", h1.CurrentHitPoints)
+	}
+	// The attack cost 1 VIG; the Eagle passive restores it at round start.
+	if h1.CurrentVIG != 3 {
+		t.Fatalf("expected vigor regen back to 3 VIG, got %d", h1.CurrentVIG)
+	}
+	for _, want := range []string{"+50% vs resting target", "Rhino Trait: P1's H1 takes 2 thorns damage", "Eagle Trait: +1 VIG"} {
+		if !strings.Contains(g.LastRoundSummary, want) {
+			t.Fatalf("expected %q in the log, got:\n%s", want, g.LastRoundSummary)
+		}
+	}
+
+	// Round 2: attack into a defending target: DEF 1 x1.5 = 1; 8 x0.5 = 4.
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionDefend
+	ResolveRound(g)
+	if h2.CurrentHitPoints != 14 {
+		t.Fatalf("expected the defend passive to halve damage, got PV=%d", h2.CurrentHitPoints)
+	}
+}
+
+func TestBuildHybridWithSynergies_MatchesAndApplies(t *testing.T) {
+	ent := func(name string, def int, tags ...string) game.Entity {
+		return game.Entity{Name: name, HitPoints: 5, Attack: 3, Defense: def, Agility: 2, Energy: 1, Tags: tags}
+	}
+	list := []game.Synergy{
+		{Name: "Apex Predators", Key: "synergy:apex", Tag: "predator", MinCount: 2, Bonus: game.SynergyBonus{Attack: 2}},
+		{Name: "Tidal Bulwark", Key: "synergy:tidal", Entities: []string{"turtle", "Octopus"}, Bonus: game.SynergyBonus{DefensePerRound: 1}},
+	}
+
+	h := BuildHybridWithSynergies([]game.Entity{ent("Lion", 2, "predator"), ent("Turtle", 2, "armored")}, 0, list)
+	if len(h.Synergies) != 0 || h.BaseAttack != 6 {
+		t.Fatalf("expected no synergy for a single predator, got %+v (ATK %d)", h.Synergies, h.BaseAttack)
+	}
+	h = BuildHybridWithSynergies([]game.Entity{ent("Lion", 2, "predator"), ent("Wolf", 2, "predator")}, 0, list)
+	if len(h.Synergies) != 1 || h.BaseAttack != 8 {
+		t.Fatalf("expected Apex Predators +2 ATK, got %+v (ATK %d)", h.Synergies, h.BaseAttack)
+	}
+
+	shell := BuildHybridWithSynergies([]game.Entity{ent("Turtle", 2), ent("Octopus", 2)}, 0, list)
+	if len(shell.Synergies) != 1 || shell.Synergies[0].Key != "synergy:tidal" {
+		t.Fatalf("expected Tidal Bulwark, got %+v", shell.Synergies)
+	}
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{shell}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{h}},
+	}}
+	PrepareMatch(g)
+	g.Players[0].PendingActionType = game.PendingActionRest
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if def := g.Players[0].Hybrids[0].CurrentDefense; def != 5 {
+		t.Fatalf("expected +1 DEF at the start of round 2, got %d", def)
+	}
+	if !strings.Contains(g.LastRoundSummary, "SYNERGY — Tidal Bulwark: +1 DEF") {
+		t.Fatalf("expected the synergy in the log, got:\n%s", g.LastRoundSummary)
+	}
+}
+
+func TestResolveRound_ConditionalEffects(t *testing.T) {
+	pounce := game.Entity{Name: "Cheetah", Skill: game.Skill{Name: "Pounce", Key: "skill:pounce", Effect: game.SkillEffect{
+		Strike:      true,
+		Conditional: []game.ConditionalEffect{{When: game.SkillCondition{OpponentAction: string(game.PendingActionRest)}, DamageBonusPercent: 50}},
+	}}}
+	shell := game.Entity{Name: "Turtle", Skill: game.Skill{Name: "Shell", Key: "skill:shell", Effect: game.SkillEffect{
+		Conditional: []game.ConditionalEffect{{When: game.SkillCondition{OpponentAction: game.ConditionOpponentAttacks}, ReflectPercent: 50}},
+	}}}
+	pounce.ID, shell.ID = 1, 2
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{pounce}, SelectedAbilityEntityID: &pounce.ID, BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 9, CurrentAttack: 9, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 3, CurrentAgility: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseEntities: []game.Entity{shell}, SelectedAbilityEntityID: &shell.ID, BaseHitPoints: 30, CurrentHitPoints: 30, BaseAttack: 1, CurrentAttack: 1, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 3, CurrentAgility: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	h1, h2 := &g.Players[0].Hybrids[0], &g.Players[1].Hybrids[0]
+
+	// Round 1: the strike reads the opponent's Rest: (9-1) x1.5 = 12.
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &pounce.ID
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+	if h2.CurrentHitPoints != 18 {
+		t.Fatalf("expected 12 damage vs a resting target, got PV=%d", h2.CurrentHitPoints)
+	}
+	if !strings.Contains(g.LastRoundSummary, "(opponent chose rest) +50% damage") {
+		t.Fatalf("expected the conditional bonus in the log, got:\n%s", g.LastRoundSummary)
+	}
+
+	// Round 2: the same strike into Defend gets no bonus, and the shell
+	// only reflects when the opponent attacks.
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &pounce.ID
+	g.Players[1].PendingActionType = game.PendingActionDefend
+	ResolveRound(g)
+	if h2.CurrentHitPoints != 10 {
+		t.Fatalf("expected 8 damage without the bonus, got PV=%d", h2.CurrentHitPoints)
+	}
+
+	// Round 3: fatigue drops DEF to 0, so a basic attack into the shell
+	// deals 9 and 5 (rounded up) is reflected.
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionAbility
+	g.Players[1].PendingActionEntityID = &shell.ID
+	ResolveRound(g)
+	if h2.CurrentHitPoints != 1 {
+		t.Fatalf("expected 9 damage on the shell, got PV=%d", h2.CurrentHitPoints)
+	}
+	if h1.CurrentHitPoints != 25 {
+		t.Fatalf("expected 5 reflected damage, got PV=%d", h1.CurrentHitPoints)
+	}
+	if !strings.Contains(g.LastRoundSummary, "P2's H2 (Shell) reflects 5 damage to P1's H1") {
+		t.Fatalf("expected the reflection in the log, got:\n%s", g.LastRoundSummary)
+	}
+}
+
+func TestResolveRound_BlockedAbilityIsSkipped(t *testing.T) {
+	roar := game.Entity{Name: "Lion", Skill: game.Skill{Name: "Roar", Key: "skill:roar", Cost: 3, Effect: game.SkillEffect{OpponentAttackDebuffPercent: 50}}}
+	roar.ID = 7
+	g := &game.Game{Players: []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{roar}, SelectedAbilityEntityID: &roar.ID, BaseHitPoints: 10, CurrentHitPoints: 10, CurrentEnergy: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	id := roar.ID
+	g.Players[0].PendingActionType = game.PendingActionAbility
+	g.Players[0].PendingActionEntityID = &id
+	g.Players[1].PendingActionType = game.PendingActionRest
+	ResolveRound(g)
+
+	h2 := &g.Players[1].Hybrids[0]
+	if len(h2.StatusEffects) != 0 {
+		t.Fatalf("expected the unaffordable ability to fizzle, got %+v", h2.StatusEffects)
+	}
+	if !strings.Contains(g.LastRoundSummary, "Roar: not enough Energy, action skipped") {
+		t.Fatalf("expected the failure in the log, got:\n%s", g.LastRoundSummary)
+	}
+}
+
+func TestResolveRound_TeamBattle(t *testing.T) {
+	fighter := func(name string) game.Hybrid {
+		return game.Hybrid{Name: name, BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 5, CurrentAttack: 5, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 3, CurrentAgility: 3, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true}
+	}
+	g := &game.Game{Mode: game.ModeTeams, Players: []game.Player{
+		{PlayerName: "A", Team: 0, Hybrids: []game.Hybrid{fighter("HA")}},
+		{PlayerName: "B", Team: 1, Hybrids: []game.Hybrid{fighter("HB")}},
+		{PlayerName: "C", Team: 0, Hybrids: []game.Hybrid{fighter("HC")}},
+		{PlayerName: "D", Team: 1, Hybrids: []game.Hybrid{fighter("HD")}},
+	}}
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	act := func(i int, a game.PendingActionType, target *int) {
+		g.Players[i].HasSubmittedAction = true
+		g.Players[i].PendingActionType = a
+		g.Players[i].PendingTargetIndex = target
+	}
+	hp := func(i int) int { return g.Players[i].Hybrids[0].CurrentHitPoints }
+
+	// Round 1: A aims at D, B attacks the first enemy in play (A).
+	d := 3
+	act(0, game.PendingActionBasicAttack, &d)
+	act(1, game.PendingActionBasicAttack, nil)
+	act(2, game.PendingActionRest, nil)
+	act(3, game.PendingActionRest, nil)
+	ResolveRound(g)
+	if hp(0) != 6 || hp(1) != 10 || hp(2) != 10 || hp(3) != 6 {
+		t.Fatalf("unexpected HP after round 1: A=%d B=%d C=%d D=%d", hp(0), hp(1), hp(2), hp(3))
+	}
+	if !strings.Contains(g.LastRoundSummary, "D's HD takes 4 damage") {
+		t.Fatalf("expected A's attack on D in the log, got:\n%s", g.LastRoundSummary)
+	}
+
+	// Round 2: B falls; the team fights on and B sits out.
+	b := 1
+	g.Players[1].Hybrids[0].CurrentHitPoints = 3
+	act(0, game.PendingActionBasicAttack, &b)
+	act(1, game.PendingActionRest, nil)
+	act(2, game.PendingActionRest, nil)
+	act(3, game.PendingActionRest, nil)
+	ResolveRound(g)
+	if g.Status != game.StatusInProgress {
+		t.Fatalf("expected the match to continue while D stands, got %s", g.Status)
+	}
+	if p := &g.Players[1]; !p.HasSubmittedAction || p.PendingActionType != game.PendingActionSkip {
+		t.Fatalf("expected the defeated player to sit out, got %+v", p)
+	}
+
+	// Round 3: C defeats D and team 0 wins.
+	g.Players[3].Hybrids[0].CurrentHitPoints = 3
+	act(0, game.PendingActionRest, nil)
+	act(2, game.PendingActionBasicAttack, nil)
+	act(3, game.PendingActionRest, nil)
+	ResolveRound(g)
+	if g.Status != game.StatusFinished || g.Winner != "A & C" || g.WinningTeam == nil || *g.WinningTeam != 0 {
+		t.Fatalf("expected team A & C to win, got status=%s winner=%q", g.Status, g.Winner)
+	}
+	if !g.IsWinner(2) || g.IsWinner(3) {
+		t.Fatalf("expected C to win and D to lose")
+	}
+}
+
+func TestResolveRound_SimultaneousKnockout(t *testing.T) {
+	// Both last hybrids are poisoned to death at round start; P2 starts
+	// the round with more HP and P1 is faster.
+	newGame := func() *game.Game {
+		poison := game.StatusEffect{Kind: game.StatusDamageOverTime, Magnitude: 9, SourceName: "Venom", StartRound: 1, RemainingRounds: 2}
+		g := &game.Game{Players: []game.Player{
+			{PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 3, BaseAttack: 5, CurrentAttack: 5, BaseAgility: 6, CurrentAgility: 6, BaseVIG: 3, CurrentVIG: 3, IsActive: true, StatusEffects: []game.StatusEffect{poison}}}},
+			{PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 5, BaseAttack: 5, CurrentAttack: 5, BaseAgility: 2, CurrentAgility: 2, BaseVIG: 3, CurrentVIG: 3, IsActive: true, StatusEffects: []game.StatusEffect{poison}}}},
+		}}
+		g.Status = game.StatusInProgress
+		g.RoundCount = 1
+		for i := range g.Players {
+			g.Players[i].PendingActionType = game.PendingActionRest
+		}
+		return g
+	}
+	resolve := func(tb game.Tiebreak) *game.Game {
+		r := game.DefaultRules()
+		r.Tiebreak = tb
+		g := newGame()
+		ResolveRoundWithRules(g, r)
+		return g
+	}
+
+	for _, tb := range []game.Tiebreak{"", game.TiebreakNone} {
+		g := resolve(tb)
+		if g.Status != game.StatusFinished || !g.Draw || g.Winner != "" || g.WinningTeam != nil {
+			t.Fatalf("tiebreak %q: expected a draw, got status=%s draw=%v winner=%q", tb, g.Status, g.Draw, g.Winner)
+		}
+		if g.IsWinner(0) || g.IsWinner(1) {
+			t.Fatalf("tiebreak %q: nobody should win a draw", tb)
+		}
+	}
+	if g := resolve(game.TiebreakRemainingHP); g.Draw || g.Winner != "P2" {
+		t.Fatalf("remaining_hp: expected P2 to win, got draw=%v winner=%q", g.Draw, g.Winner)
+	}
+	if g := resolve(game.TiebreakAgility); g.Draw || g.Winner != "P1" {
+		t.Fatalf("agility: expected P1 to win, got draw=%v winner=%q", g.Draw, g.Winner)
+	}
+
+	// Sudden death: both return with 1 HP; the faster attacker wins.
+	r := game.DefaultRules()
+	r.Tiebreak = game.TiebreakSuddenDeath
+	g := newGame()
+	ResolveRoundWithRules(g, r)
+	h1, h2 := &g.Players[0].Hybrids[0], &g.Players[1].Hybrids[0]
+	if g.Status != game.StatusInProgress || !g.SuddenDeath || h1.CurrentHitPoints != 1 || h2.CurrentHitPoints != 1 || h1.IsDefeated || !h2.IsActive {
+		t.Fatalf("expected a sudden-death round, got status=%s h1=%+v h2=%+v", g.Status, h1, h2)
+	}
+	if !strings.Contains(g.LastRoundSummary, "SUDDEN DEATH") {
+		t.Fatalf("expected the sudden death in the log, got:\n%s", g.LastRoundSummary)
+	}
+	g.Players[0].PendingActionType = game.PendingActionBasicAttack
+	g.Players[1].PendingActionType = game.PendingActionBasicAttack
+	ResolveRoundWithRules(g, r)
+	if g.Status != game.StatusFinished || g.Draw || g.Winner != "P1" {
+		t.Fatalf("expected P1 to win the sudden-death round, got status=%s draw=%v winner=%q", g.Status, g.Draw, g.Winner)
+	}
+
+	// Nobody falls in the sudden-death round: draw.
+	g = newGame()
+	ResolveRoundWithRules(g, r)
+	ResolveRoundWithRules(g, r)
+	if g.Status != game.StatusFinished || !g.Draw {
+		t.Fatalf("expected a draw after a quiet sudden-death round, got status=%s draw=%v", g.Status, g.Draw)
+	}
+}
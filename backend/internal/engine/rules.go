@@ -0,0 +1,27 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// rules is the ruleset used by ResolveRound and PrepareMatch. It is set
+// once at startup from the configuration.
+var rules = game.DefaultRules()
+
+// SetRules sets the ruleset used to resolve matches.
+func SetRules(r game.Rules) {
+	rules = r
+}
+
+// CurrentRules returns the ruleset in effect.
+func CurrentRules() game.Rules {
+	return rules
+}
+
+// RulesFor returns the ruleset g is played with: the one recorded when the
+// match started, or the configured one for matches started before rules
+// were recorded.
+func RulesFor(g *game.Game) game.Rules {
+	if g.Rules != nil {
+		return *g.Rules
+	}
+	return rules
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// BuildHybrid combines 2–3 entities into a hybrid: stats are summed, base
+// energy is clamped to 1..3, the configured synergies are matched and
+// selectedEntityID defines the ability the hybrid can use. Callers are
+// responsible for validating the inputs.
+func BuildHybrid(entities []game.Entity, selectedEntityID uint) game.Hybrid {
+	return BuildHybridWithSynergies(entities, selectedEntityID, synergies)
+}
+
+// BuildHybridWithSynergies builds a hybrid like BuildHybrid matching the
+// given synergies instead of the configured ones.
+func BuildHybridWithSynergies(entities []game.Entity, selectedEntityID uint, list []game.Synergy) game.Hybrid {
+	hp, atk, def, agi, ene := 0, 0, 0, 0, 0
+	for _, e := range entities {
+		hp += e.HitPoints
+		atk += e.Attack
+		def += e.Defense
+		agi += e.Agility
+		ene += e.Energy
+	}
+	if ene < 1 {
+		ene = 1
+	}
+	if ene > 3 {
+		ene = 3
+	}
+	sel := selectedEntityID
+	h := game.Hybrid{
+		Name:                    CombinedName(entities),
+		BaseEntities:            entities,
+		BaseHitPoints:           hp,
+		BaseAttack:              atk,
+		BaseDefense:             def,
+		BaseAgility:             agi,
+		BaseEnergy:              ene,
+		SelectedAbilityEntityID: &sel,
+	}
+	applySynergies(&h, list)
+	return h
+}
+
+// CombinedName returns the derived display name of a hybrid made of the
+// given entities (names sorted case-insensitively, e.g. "Lion + Raven").
+func CombinedName(entities []game.Entity) string {
+	names := make([]string, len(entities))
+	for i := range entities {
+		names[i] = entities[i].Name
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	return strings.Join(names, " + ")
+}
+
+// PrepareMatch puts a game whose players already own their hybrids into
+// the state of round 1: combat stats are reset to base values, each
+// player's first hybrid enters the arena and round-start adjustments are
+// applied.
+func PrepareMatch(g *game.Game) {
+	PrepareMatchWithRules(g, RulesFor(g))
+}
+
+// PrepareMatchWithRules prepares the match like PrepareMatch using the
+// given ruleset instead of the configured one.
+func PrepareMatchWithRules(g *game.Game, r game.Rules) {
+	for i := range g.Players {
+		for j := range g.Players[i].Hybrids {
+			hbd := &g.Players[i].Hybrids[j]
+			hbd.CurrentHitPoints = hbd.BaseHitPoints
+			hbd.CurrentAttack = hbd.BaseAttack
+			hbd.CurrentDefense = hbd.BaseDefense
+			hbd.CurrentAgility = hbd.BaseAgility
+			hbd.CurrentEnergy = hbd.BaseEnergy
+			// Initialize VIG: base and current
+			if hbd.BaseVIG == 0 {
+				hbd.BaseVIG = r.BaseVigor
+			}
+			hbd.CurrentVIG = hbd.BaseVIG
+			hbd.IsDefeated = false
+			hbd.StatusEffects = nil
+			hbd.IsActive = (j == 0)
+			hbd.Revealed = (j == 0)
+		}
+	}
+
+	g.Status = game.StatusInProgress
+	g.RoundCount = 1
+	g.TurnNumber = 1
+	g.Phase = game.PhasePlanning
+	g.Message = "The game has started. Choose your actions."
+
+	// Round start adjustments
+	for i := range g.Players {
+		g.Players[i].HasSubmittedAction = false
+		g.Players[i].PendingActionType = game.PendingActionNone
+		g.Players[i].PendingActionEntityID = nil
+		g.Players[i].PendingSwitchIndex = nil
+		g.Players[i].PendingTargetIndex = nil
+		for j := range g.Players[i].Hybrids {
+			if g.Players[i].Hybrids[j].IsActive && !g.Players[i].Hybrids[j].IsDefeated {
+				g.Players[i].Hybrids[j].CurrentEnergy += r.EnergyPerRound
+				g.Players[i].Hybrids[j].LastAction = ""
+				g.Players[i].Hybrids[j].DefendStanceActive = false
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// applySwitch performs a pending `switch` action: the active hybrid goes
+// to the reserve keeping its current HP/VIG/ENE and status effects, and
+// the chosen reserve enters the arena. It reports whether the active
+// hybrid changed.
+func (rc *roundContext) applySwitch(p *game.Player) bool {
+	if p.PendingActionType != game.PendingActionSwitch {
+		return false
+	}
+	out := ActiveHybrid(p)
+	in := SwitchTarget(p, p.PendingSwitchIndex)
+	if out == nil || in == nil {
+		return false
+	}
+	out.IsActive = false
+	out.DefendStanceActive = false
+	out.LastAction = string(game.PendingActionSwitch)
+	rc.emit(rc.event(game.EventSwitchedOut, p, out))
+	rc.enterArena(p, in)
+	return true
+}
+
+// SwitchTarget returns the reserve a `switch` action would bring in: the
+// hybrid at idx when it is a valid reserve, or the first non-defeated
+// reserve when idx is nil. It returns nil when there is no valid target.
+func SwitchTarget(p *game.Player, idx *int) *game.Hybrid {
+	valid := func(h *game.Hybrid) bool { return !h.IsActive && !h.IsDefeated }
+	if idx != nil {
+		if *idx < 0 || *idx >= len(p.Hybrids) || !valid(&p.Hybrids[*idx]) {
+			return nil
+		}
+		return &p.Hybrids[*idx]
+	}
+	for i := range p.Hybrids {
+		if valid(&p.Hybrids[i]) {
+			return &p.Hybrids[i]
+		}
+	}
+	return nil
+}
+
+// enterArena makes h the player's active hybrid. A hybrid entering for the
+// first time starts from its base stats; one that was switched out
+// earlier returns exactly as it left.
+func (rc *roundContext) enterArena(p *game.Player, h *game.Hybrid) {
+	h.IsActive = true
+	if !h.Revealed {
+		h.Revealed = true
+		h.CurrentHitPoints = h.BaseHitPoints
+		h.CurrentAttack = h.BaseAttack
+		h.CurrentDefense = h.BaseDefense
+		h.CurrentAgility = h.BaseAgility
+		h.CurrentEnergy = h.BaseEnergy
+	}
+	rc.emit(rc.event(game.EventReserveEntered, p, h))
+}
@@ -0,0 +1,53 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// synergies are the combination bonuses matched by BuildHybrid. They are
+// set once at startup from the configuration.
+var synergies []game.Synergy
+
+// SetSynergies sets the synergies matched when hybrids are built.
+func SetSynergies(s []game.Synergy) {
+	synergies = s
+}
+
+// CurrentSynergies returns the configured synergies.
+func CurrentSynergies() []game.Synergy {
+	return synergies
+}
+
+// applySynergies records the synergies matched by the hybrid's base
+// entities and adds their flat stat bonuses to its base stats.
+func applySynergies(h *game.Hybrid, list []game.Synergy) {
+	h.Synergies = nil
+	for _, s := range list {
+		if !s.Matches(h.BaseEntities) {
+			continue
+		}
+		h.Synergies = append(h.Synergies, s)
+		h.BaseHitPoints += s.Bonus.HitPoints
+		h.BaseAttack += s.Bonus.Attack
+		h.BaseDefense += s.Bonus.Defense
+		h.BaseAgility += s.Bonus.Agility
+		h.BaseEnergy += s.Bonus.Energy
+	}
+}
+
+// applySynergyRegen applies the per-round synergy bonuses of the active
+// hybrid at the start of a round.
+func (rc *roundContext) applySynergyRegen(p *game.Player, h *game.Hybrid) {
+	for _, s := range h.Synergies {
+		b := s.Bonus
+		if b.DefensePerRound == 0 && b.EnergyPerRound == 0 {
+			continue
+		}
+		h.CurrentDefense += b.DefensePerRound
+		h.CurrentEnergy += b.EnergyPerRound
+		ev := rc.event(game.EventSynergyTriggered, p, h)
+		ev.Skill = s.Name
+		ev.SkillKey = s.Key
+		ev.Defense = b.DefensePerRound
+		ev.Energy = b.EnergyPerRound
+		rc.emit(ev)
+	}
+}
@@ -0,0 +1,88 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// ActionTarget returns the enemy player p aims its action at: the player
+// at idx when it is an enemy with a hybrid in the arena, or the first such
+// enemy when idx is nil. It returns nil when there is no valid target.
+func ActionTarget(g *game.Game, p *game.Player, idx *int) *game.Player {
+	self := playerIndex(g, p)
+	if self < 0 {
+		return nil
+	}
+	if idx != nil {
+		if *idx < 0 || *idx >= len(g.Players) || !enemyInPlay(g, self, *idx) {
+			return nil
+		}
+		return &g.Players[*idx]
+	}
+	if enemies := EnemiesInPlay(g, self); len(enemies) > 0 {
+		return &g.Players[enemies[0]]
+	}
+	return nil
+}
+
+// EnemiesInPlay returns the indexes of the enemies of the player at idx
+// that have a hybrid in the arena, in player order. They are the players
+// an attack or ability of idx may target.
+func EnemiesInPlay(g *game.Game, idx int) []int {
+	var out []int
+	for i := range g.Players {
+		if enemyInPlay(g, idx, i) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// enemyInPlay reports whether the player at i is an enemy of the player at
+// self with a hybrid in the arena.
+func enemyInPlay(g *game.Game, self, i int) bool {
+	return g.TeamOf(i) != g.TeamOf(self) && ActiveHybrid(&g.Players[i]) != nil
+}
+
+// combatant is a player taking part in the round with its active hybrid.
+type combatant struct {
+	player *game.Player
+	hybrid *game.Hybrid
+}
+
+// combatants returns the players that have a hybrid in the arena, in
+// player order.
+func (rc *roundContext) combatants() []combatant {
+	var out []combatant
+	for i := range rc.g.Players {
+		p := &rc.g.Players[i]
+		if h := ActiveHybrid(p); h != nil {
+			out = append(out, combatant{player: p, hybrid: h})
+		}
+	}
+	return out
+}
+
+// lockTargets fixes every combatant's target for the rest of the round. A
+// chosen target that left the arena falls back to the first enemy in
+// play.
+func (rc *roundContext) lockTargets(cs []combatant) {
+	rc.targets = make(map[*game.Player]combatant, len(cs))
+	for _, c := range cs {
+		t := ActionTarget(rc.g, c.player, c.player.PendingTargetIndex)
+		if t == nil {
+			t = ActionTarget(rc.g, c.player, nil)
+		}
+		for _, o := range cs {
+			if o.player == t {
+				rc.targets[c.player] = o
+			}
+		}
+	}
+}
+
+// teamsInPlay reports whether every team still has a hybrid in the arena.
+func teamsInPlay(g *game.Game, cs []combatant) bool {
+	seen := make(map[int]bool, game.TeamCount)
+	for _, c := range cs {
+		seen[g.TeamOf(playerIndex(g, c.player))] = true
+	}
+	return len(seen) == game.TeamCount
+}
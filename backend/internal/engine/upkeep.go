@@ -0,0 +1,45 @@
+package engine
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// applyUpkeep resolves the round-start ticks of over-time effects on the
+// player's active hybrid: healing first, then damage. A hybrid brought to
+// 0 HP here is defeated before it can act.
+func (rc *roundContext) applyUpkeep(p *game.Player, h *game.Hybrid) {
+	round := rc.g.RoundCount
+	for _, e := range h.ActiveEffects(game.StatusHealOverTime, round) {
+		ev := rc.event(game.EventHealed, p, h)
+		ev.Effect = game.EffectHealOverTime
+		ev.Skill = e.SourceName
+		ev.SkillKey = e.SourceSkill
+		ev.Amount = heal(h, e.Magnitude)
+		rc.emit(ev)
+	}
+	for _, e := range h.ActiveEffects(game.StatusDamageOverTime, round) {
+		if h.IsDefeated {
+			break
+		}
+		h.CurrentHitPoints -= e.Magnitude
+		ev := rc.event(game.EventStatusDamage, p, h)
+		ev.Effect = game.EffectDamageOverTime
+		ev.Skill = e.SourceName
+		ev.SkillKey = e.SourceSkill
+		ev.Amount = e.Magnitude
+		rc.emit(ev)
+		rc.defeat(p, h)
+	}
+}
+
+// heal restores up to amount HP without exceeding BaseHitPoints and
+// returns the HP actually regained.
+func heal(h *game.Hybrid, amount int) int {
+	prev := h.CurrentHitPoints
+	h.CurrentHitPoints += amount
+	if h.CurrentHitPoints > h.BaseHitPoints {
+		h.CurrentHitPoints = h.BaseHitPoints
+	}
+	if h.CurrentHitPoints < prev {
+		h.CurrentHitPoints = prev
+	}
+	return h.CurrentHitPoints - prev
+}
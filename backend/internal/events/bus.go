@@ -0,0 +1,168 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/logging"
+)
+
+// Mode selects how a subscriber receives events.
+type Mode int
+
+const (
+	// Sync subscribers run inside Publish, in subscription order, before
+	// Publish returns.
+	Sync Mode = iota
+	// Async subscribers run on their own goroutine and receive events in
+	// publication order. Publish never waits for them: when a subscriber
+	// falls asyncQueue events behind, further events are dropped for it
+	// and logged.
+	Async
+)
+
+// asyncQueue is the number of events waiting for an async subscriber
+// before new ones are dropped.
+const asyncQueue = 256
+
+type subscriber struct {
+	name    string
+	handle  func(Event)
+	mode    Mode
+	queue   chan Event
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// Bus delivers published events to its subscribers. A nil *Bus is valid:
+// it drops every event and ignores subscriptions, so code publishing
+// events works without one.
+type Bus struct {
+	mu     sync.Mutex
+	subs   []*subscriber
+	closed bool
+}
+
+// NewBus returns a bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers fn for the events of type E. name identifies the
+// subscriber in logs.
+func Subscribe[E Event](b *Bus, name string, mode Mode, fn func(E)) {
+	b.add(name, mode, func(e Event) {
+		if ev, ok := e.(E); ok {
+			fn(ev)
+		}
+	})
+}
+
+// SubscribeAll registers fn for every event.
+func SubscribeAll(b *Bus, name string, mode Mode, fn func(Event)) {
+	b.add(name, mode, fn)
+}
+
+func (b *Bus) add(name string, mode Mode, fn func(Event)) {
+	if b == nil {
+		return
+	}
+	s := &subscriber{name: name, handle: fn, mode: mode}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	if mode == Async {
+		s.queue = make(chan Event, asyncQueue)
+		s.done = make(chan struct{})
+		s.stopped = make(chan struct{})
+		go s.run()
+	}
+	b.subs = append(b.subs, s)
+}
+
+// Publish delivers e to every subscriber. The lock is only held to read
+// the subscribers, so subscribers may publish or subscribe themselves. A
+// subscriber that panics is logged and does not affect the publisher or
+// the other subscribers. Events published after Close are dropped.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	subs := b.subs
+	b.mu.Unlock()
+	for _, s := range subs {
+		if s.mode == Sync {
+			s.deliver(e)
+			continue
+		}
+		select {
+		case s.queue <- e:
+		default:
+			logging.Error("event dropped for a slow subscriber", nil, logging.Fields{
+				"subscriber": s.name, "event": e.Name(), constants.LogFieldGameID: e.GameID(),
+			})
+		}
+	}
+}
+
+// Close stops accepting events and waits until the async subscribers
+// handled the ones already queued.
+func (b *Bus) Close() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subs := b.subs
+	b.mu.Unlock()
+	for _, s := range subs {
+		if s.mode == Async {
+			close(s.done)
+			<-s.stopped
+		}
+	}
+}
+
+// run delivers the queued events until the bus is closed, then drains
+// the queue.
+func (s *subscriber) run() {
+	defer close(s.stopped)
+	for {
+		select {
+		case e := <-s.queue:
+			s.deliver(e)
+		case <-s.done:
+			for {
+				select {
+				case e := <-s.queue:
+					s.deliver(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *subscriber) deliver(e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Error("event subscriber panicked", fmt.Errorf("%v", r), logging.Fields{
+				"subscriber": s.name, "event": e.Name(), constants.LogFieldGameID: e.GameID(),
+			})
+		}
+	}()
+	s.handle(e)
+}
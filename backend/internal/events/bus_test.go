@@ -0,0 +1,140 @@
+package events
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func testGame(id uint) *game.Game {
+	g := &game.Game{}
+	g.ID = id
+	return g
+}
+
+func TestBus_SyncDeliversTypedEventsInOrder(t *testing.T) {
+	b := NewBus()
+	var got []string
+	Subscribe(b, "finished", Sync, func(e GameFinished) {
+		got = append(got, "finished:"+string(e.Reason)+":"+e.ResignedEmail)
+	})
+	SubscribeAll(b, "all", Sync, func(e Event) { got = append(got, e.Name()) })
+
+	b.Publish(NewGameCreated(testGame(1)))
+	b.Publish(NewGameFinished(testGame(1), FinishResigned, "a@example.com"))
+
+	want := []string{"game_created", "finished:resigned:a@example.com", "game_finished"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestBus_AsyncDeliversAllBeforeClose(t *testing.T) {
+	b := NewBus()
+	var mu sync.Mutex
+	var ids []uint
+	Subscribe(b, "rounds", Async, func(e RoundResolved) {
+		mu.Lock()
+		ids = append(ids, e.GameID())
+		mu.Unlock()
+	})
+	for i := 1; i <= 200; i++ {
+		b.Publish(NewRoundResolved(testGame(uint(i)), nil))
+	}
+	b.Close()
+	b.Publish(NewRoundResolved(testGame(999), nil))
+
+	if len(ids) != 200 {
+		t.Fatalf("expected 200 events, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != uint(i+1) {
+			t.Fatalf("events out of order at %d: %v", i, id)
+		}
+	}
+}
+
+func TestBus_PanickingSubscriberIsIsolated(t *testing.T) {
+	b := NewBus()
+	SubscribeAll(b, "broken", Sync, func(Event) { panic("boom") })
+	SubscribeAll(b, "broken-async", Async, func(Event) { panic("boom") })
+	calls := 0
+	Subscribe(b, "ok", Sync, func(PlayerJoined) { calls++ })
+
+	b.Publish(NewPlayerJoined(testGame(1), 1))
+	b.Publish(NewPlayerJoined(testGame(1), 2))
+	b.Close()
+	if calls != 2 {
+		t.Fatalf("expected the other subscriber to get both events, got %d", calls)
+	}
+}
+
+func TestBus_SubscriberCanPublishAndSubscribe(t *testing.T) {
+	b := NewBus()
+	var got []string
+	Subscribe(b, "starter", Sync, func(e GameStarted) {
+		// Subscribing takes the bus lock: it must not be held while the
+		// subscribers run.
+		SubscribeAll(b, "late", Sync, func(e Event) { got = append(got, "late:"+e.Name()) })
+		b.Publish(NewRoundResolved(e.Game, nil))
+	})
+	SubscribeAll(b, "all", Sync, func(e Event) { got = append(got, e.Name()) })
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(NewGameStarted(testGame(1)))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing from a subscriber deadlocked")
+	}
+	want := []string{"round_resolved", "late:round_resolved", "game_started"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestBus_FullAsyncQueueDropsInsteadOfBlocking(t *testing.T) {
+	b := NewBus()
+	release := make(chan struct{})
+	var mu sync.Mutex
+	handled := 0
+	SubscribeAll(b, "slow", Async, func(Event) {
+		<-release
+		mu.Lock()
+		handled++
+		mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*asyncQueue; i++ {
+			b.Publish(NewGameCreated(testGame(uint(i))))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+	close(release)
+	b.Close()
+	if handled == 0 || handled > asyncQueue+1 {
+		t.Fatalf("expected the events past the queue to be dropped, handled %d", handled)
+	}
+}
@@ -0,0 +1,171 @@
+// Package events is the in-process bus of domain events: the services
+// publish what happened to a game (created, joined, started, a round
+// resolved, finished, ...) and subscribers such as the event log or the
+// updates streamed to clients react to it without the publishers knowing
+// about them.
+package events
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// Event is a domain event. Every event is about one game; Game holds its
+// state right after the change was saved. Events are shared between
+// subscribers and must not be modified.
+type Event interface {
+	// Name identifies the kind of event in logs ("game_created", ...).
+	Name() string
+	// GameID is the ID of the game the event is about.
+	GameID() uint
+	// State is the game right after the change was saved.
+	State() *game.Game
+}
+
+// gameEvent carries the game every event is about.
+type gameEvent struct {
+	Game *game.Game
+}
+
+func (e gameEvent) GameID() uint {
+	if e.Game == nil {
+		return 0
+	}
+	return e.Game.ID
+}
+
+func (e gameEvent) State() *game.Game { return e.Game }
+
+// GameCreated: a player created a game and took its first seat.
+type GameCreated struct{ gameEvent }
+
+// PlayerJoined: the player at PlayerIndex (a person or a bot) joined the
+// game.
+type PlayerJoined struct {
+	gameEvent
+	PlayerIndex int
+}
+
+// PlayerLeft: the player at PlayerIndex left the waiting room. Game no
+// longer lists them.
+type PlayerLeft struct {
+	gameEvent
+	PlayerIndex int
+}
+
+// HybridsCreated: the player at PlayerIndex built their hybrids.
+type HybridsCreated struct {
+	gameEvent
+	PlayerIndex int
+}
+
+// GameStarting: a player started the match; its hybrids are being
+// generated.
+type GameStarting struct{ gameEvent }
+
+// GameStartFailed: generating the hybrids failed and the game is in the
+// error status.
+type GameStartFailed struct{ gameEvent }
+
+// GameStarted: the hybrids were generated and the first round is being
+// planned.
+type GameStarted struct{ gameEvent }
+
+// ActionSubmitted: the player at PlayerIndex submitted the round's action
+// and the round waits for other players. The round that every player
+// submitted for is reported by RoundResolved instead.
+type ActionSubmitted struct {
+	gameEvent
+	PlayerIndex int
+}
+
+// RoundResolved: a round was resolved; Round is its history record.
+type RoundResolved struct {
+	gameEvent
+	Round *game.GameRound
+}
+
+// FinishReason tells how a match ended.
+type FinishReason string
+
+const (
+	// FinishDecided: the match was won or drawn in the arena.
+	FinishDecided FinishReason = "decided"
+	// FinishResigned: a player resigned (see PlayerResigned).
+	FinishResigned FinishReason = "resigned"
+	// FinishInactivity: nobody acted before the deadline; the match counts
+	// for nobody.
+	FinishInactivity FinishReason = "inactivity"
+)
+
+// GameFinished: the match is over. ResignedEmail is the player who
+// resigned when Reason is FinishResigned.
+type GameFinished struct {
+	gameEvent
+	Reason        FinishReason
+	ResignedEmail string
+}
+
+// PlayerResigned: the player at PlayerIndex resigned. It is followed by
+// the GameFinished event of the match.
+type PlayerResigned struct {
+	gameEvent
+	PlayerIndex int
+}
+
+func (GameCreated) Name() string     { return "game_created" }
+func (PlayerJoined) Name() string    { return "player_joined" }
+func (PlayerLeft) Name() string      { return "player_left" }
+func (HybridsCreated) Name() string  { return "hybrids_created" }
+func (GameStarting) Name() string    { return "game_starting" }
+func (GameStartFailed) Name() string { return "game_start_failed" }
+func (GameStarted) Name() string     { return "game_started" }
+func (ActionSubmitted) Name() string { return "action_submitted" }
+func (RoundResolved) Name() string   { return "round_resolved" }
+func (GameFinished) Name() string    { return "game_finished" }
+func (PlayerResigned) Name() string  { return "player_resigned" }
+
+// NewGameCreated returns the GameCreated event of g.
+func NewGameCreated(g *game.Game) GameCreated { return GameCreated{gameEvent{g}} }
+
+// NewPlayerJoined returns the PlayerJoined event of the player at idx.
+func NewPlayerJoined(g *game.Game, idx int) PlayerJoined {
+	return PlayerJoined{gameEvent{g}, idx}
+}
+
+// NewPlayerLeft returns the PlayerLeft event of the player who was at idx.
+func NewPlayerLeft(g *game.Game, idx int) PlayerLeft {
+	return PlayerLeft{gameEvent{g}, idx}
+}
+
+// NewHybridsCreated returns the HybridsCreated event of the player at idx.
+func NewHybridsCreated(g *game.Game, idx int) HybridsCreated {
+	return HybridsCreated{gameEvent{g}, idx}
+}
+
+// NewGameStarting returns the GameStarting event of g.
+func NewGameStarting(g *game.Game) GameStarting { return GameStarting{gameEvent{g}} }
+
+// NewGameStartFailed returns the GameStartFailed event of g.
+func NewGameStartFailed(g *game.Game) GameStartFailed { return GameStartFailed{gameEvent{g}} }
+
+// NewGameStarted returns the GameStarted event of g.
+func NewGameStarted(g *game.Game) GameStarted { return GameStarted{gameEvent{g}} }
+
+// NewActionSubmitted returns the ActionSubmitted event of the player at
+// idx.
+func NewActionSubmitted(g *game.Game, idx int) ActionSubmitted {
+	return ActionSubmitted{gameEvent{g}, idx}
+}
+
+// NewRoundResolved returns the RoundResolved event of round r of g.
+func NewRoundResolved(g *game.Game, r *game.GameRound) RoundResolved {
+	return RoundResolved{gameEvent{g}, r}
+}
+
+// NewGameFinished returns the GameFinished event of g.
+func NewGameFinished(g *game.Game, reason FinishReason, resignedEmail string) GameFinished {
+	return GameFinished{gameEvent{g}, reason, resignedEmail}
+}
+
+// NewPlayerResigned returns the PlayerResigned event of the player at idx.
+func NewPlayerResigned(g *game.Game, idx int) PlayerResigned {
+	return PlayerResigned{gameEvent{g}, idx}
+}
@@ -0,0 +1,59 @@
+package game
+
+// Clone returns a copy of the game that the engine can resolve rounds on
+// without touching g: players, hybrids, status effects, cooldowns and
+// pending choices are copied. Entity definitions, synergies and recorded
+// round events are shared since they are never modified once built.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.WinningTeam = cloneInt(g.WinningTeam)
+	c.LastRoundEvents = append([]RoundEvent(nil), g.LastRoundEvents...)
+	c.Players = make([]Player, len(g.Players))
+	for i := range g.Players {
+		c.Players[i] = g.Players[i].clone()
+	}
+	return &c
+}
+
+func (p *Player) clone() Player {
+	c := *p
+	c.PendingActionEntityID = cloneUint(p.PendingActionEntityID)
+	c.PendingSwitchIndex = cloneInt(p.PendingSwitchIndex)
+	c.NextReserveIndex = cloneInt(p.NextReserveIndex)
+	c.PendingTargetIndex = cloneInt(p.PendingTargetIndex)
+	c.Hybrids = make([]Hybrid, len(p.Hybrids))
+	for i := range p.Hybrids {
+		c.Hybrids[i] = p.Hybrids[i].clone()
+	}
+	return c
+}
+
+func (h *Hybrid) clone() Hybrid {
+	c := *h
+	c.BaseEntities = append([]Entity(nil), h.BaseEntities...)
+	c.SelectedAbilityEntityID = cloneUint(h.SelectedAbilityEntityID)
+	c.StatusEffects = append([]StatusEffect(nil), h.StatusEffects...)
+	if h.AbilityCooldowns != nil {
+		c.AbilityCooldowns = make(map[uint]int, len(h.AbilityCooldowns))
+		for id, n := range h.AbilityCooldowns {
+			c.AbilityCooldowns[id] = n
+		}
+	}
+	return c
+}
+
+func cloneInt(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneUint(p *uint) *uint {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
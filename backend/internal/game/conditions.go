@@ -0,0 +1,65 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConditionOpponentAttacks matches an opponent that makes a basic attack
+// or uses a striking ability this round.
+const ConditionOpponentAttacks = "attack"
+
+// SkillCondition restricts when a ConditionalEffect applies. It is checked
+// when the ability is used, after both players' actions are revealed;
+// every field that is set must hold and an empty condition always holds.
+type SkillCondition struct {
+	// OpponentAction is the opponent's action this round: a
+	// PendingActionType value (e.g. "rest") or ConditionOpponentAttacks.
+	OpponentAction string `json:"opponent_action,omitempty"`
+	// SelfHPBelowPercent holds while the user's HP is below that
+	// percentage of its base HP; OpponentHPBelowPercent checks the
+	// opponent's active hybrid.
+	SelfHPBelowPercent     int `json:"self_hp_below_percent,omitempty"`
+	OpponentHPBelowPercent int `json:"opponent_hp_below_percent,omitempty"`
+}
+
+// ConditionalEffect is an extra effect of a skill that only applies when
+// When holds.
+type ConditionalEffect struct {
+	When SkillCondition `json:"when"`
+	// DamageBonusPercent raises the damage of the user's attacks this
+	// round (only useful on striking abilities).
+	DamageBonusPercent int `json:"damage_bonus_percent,omitempty"`
+	// ReflectPercent returns that percentage of every hit the user takes
+	// this round to the attacker (at least 1 damage).
+	ReflectPercent int `json:"reflect_percent,omitempty"`
+	// Heal restores HP to the user immediately (capped at base HP).
+	Heal int `json:"heal,omitempty"`
+}
+
+// Validate reports the first field of the conditional effect that is out
+// of range.
+func (ce ConditionalEffect) Validate() error {
+	switch PendingActionType(ce.When.OpponentAction) {
+	case "", PendingActionBasicAttack, PendingActionDefend, PendingActionAbility,
+		PendingActionRest, PendingActionSwitch, ConditionOpponentAttacks:
+	default:
+		return fmt.Errorf("unknown opponent_action %q", ce.When.OpponentAction)
+	}
+	switch {
+	case ce.When.SelfHPBelowPercent < 0 || ce.When.SelfHPBelowPercent > 100:
+		return errors.New("self_hp_below_percent must be in [0, 100]")
+	case ce.When.OpponentHPBelowPercent < 0 || ce.When.OpponentHPBelowPercent > 100:
+		return errors.New("opponent_hp_below_percent must be in [0, 100]")
+	case ce.DamageBonusPercent < 0 || ce.ReflectPercent < 0 || ce.Heal < 0:
+		return errors.New("effect values must be >= 0")
+	case ce.DamageBonusPercent == 0 && ce.ReflectPercent == 0 && ce.Heal == 0:
+		return errors.New("no effect configured")
+	}
+	return nil
+}
+
+// HPBelow reports whether h has less than pct percent of its base HP.
+func (h *Hybrid) HPBelow(pct int) bool {
+	return h.CurrentHitPoints*100 < h.BaseHitPoints*pct
+}
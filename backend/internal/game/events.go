@@ -0,0 +1,202 @@
+package game
+
+// RoundEventType identifies what happened in a RoundEvent. Values are part
+// of the public API (clients switch on them to drive animations), so only
+// add new values; never rename existing ones.
+type RoundEventType string
+
+const (
+	// EventCostPaid: the actor paid the VIG/ENE cost of its action.
+	EventCostPaid RoundEventType = "cost_paid"
+	// EventBuffApplied: a beneficial effect was applied to the actor.
+	EventBuffApplied RoundEventType = "buff_applied"
+	// EventDebuffApplied: a harmful effect was applied to the target.
+	EventDebuffApplied RoundEventType = "debuff_applied"
+	// EventEnergyRestored: an ability restored energy to the actor.
+	EventEnergyRestored RoundEventType = "energy_restored"
+	// EventRested: the actor rested and regained VIG/ENE.
+	EventRested RoundEventType = "rested"
+	// EventStunned: the actor was stunned and skipped its action.
+	EventStunned RoundEventType = "stunned"
+	// EventStunResisted: a stun attempt against the target failed its
+	// chance roll (Percent is the chance that was rolled against).
+	EventStunResisted RoundEventType = "stun_resisted"
+	// EventDamageDealt: the actor hit the target; see Damage.
+	EventDamageDealt RoundEventType = "damage_dealt"
+	// EventAttackDodged: the target evaded the actor's attack (Percent is
+	// the dodge chance that was rolled).
+	EventAttackDodged RoundEventType = "attack_dodged"
+	// EventHybridDefeated: the player's hybrid dropped to 0 HP.
+	EventHybridDefeated RoundEventType = "hybrid_defeated"
+	// EventReserveEntered: the player's reserve hybrid entered the arena.
+	EventReserveEntered RoundEventType = "reserve_entered"
+	// EventSwitchedOut: the player voluntarily withdrew Hybrid from the
+	// arena (a reserve_entered event for the incoming hybrid follows).
+	EventSwitchedOut RoundEventType = "switched_out"
+	// EventFatigueApplied: battle fatigue reduced the hybrid's DEF.
+	EventFatigueApplied RoundEventType = "fatigue_applied"
+	// EventStatusDamage: an over-time effect damaged the hybrid at round
+	// start (Amount is the HP lost).
+	EventStatusDamage RoundEventType = "status_damage"
+	// EventPassiveTriggered: a passive trait of the hybrid took effect
+	// (Effect is the trait, e.g. thorns; Skill names the passive(s) and
+	// Amount is the HP dealt or VIG restored). For thorns the target is
+	// the attacker that was hurt.
+	EventPassiveTriggered RoundEventType = "passive_triggered"
+	// EventSynergyTriggered: a per-round synergy bonus was applied to the
+	// hybrid at round start (Skill names the synergy; Defense/Energy are
+	// the amounts gained).
+	EventSynergyTriggered RoundEventType = "synergy_triggered"
+	// EventHealed: the hybrid regained Amount HP, instantly from an
+	// ability or from an over-time effect (Effect is set).
+	EventHealed RoundEventType = "healed"
+	// EventDamageReflected: a reflect effect of the hybrid returned
+	// Amount damage to the attacker (the target).
+	EventDamageReflected RoundEventType = "damage_reflected"
+	// EventAbilityFailed: the actor's ability could not be used (Reason
+	// is not_enough_energy or on_cooldown; Duration is the remaining
+	// cooldown) and its action was skipped.
+	EventAbilityFailed RoundEventType = "ability_failed"
+	// EventSuddenDeath: the last hybrids of every side fell together and
+	// came back with 1 HP for a round that decides the match (see
+	// TiebreakSuddenDeath). It has no player.
+	EventSuddenDeath RoundEventType = "sudden_death"
+)
+
+// Effect names used by buff_applied/debuff_applied events.
+const (
+	EffectAttackBuff        = "attack_buff"
+	EffectAttackDebuff      = "attack_debuff"
+	EffectAgilityDebuff     = "agility_debuff"
+	EffectDefenseMultiplier = "defense_multiplier"
+	EffectIgnoreDefense     = "ignore_defense"
+	EffectAgilityDamage     = "agility_damage"
+	EffectCannotAttack      = "cannot_attack"
+	EffectStun              = "stun"
+	EffectDamageOverTime    = "damage_over_time"
+	EffectHealOverTime      = "heal_over_time"
+	EffectDamageBonus       = "damage_bonus"
+	EffectReflect           = "reflect"
+)
+
+// Damage multiplier and defense modifier sources reported in a
+// DamageBreakdown.
+const (
+	DamageSourceZeroVigor      = "zero_vigor"
+	DamageSourceVulnerable     = "vulnerable"
+	DamageSourceCritical       = "critical"
+	DamageSourceRestingTarget  = "resting_target"
+	DamageSourceDefendPassive  = "defend_passive"
+	DamageSourceConditional    = "conditional_bonus"
+	DefenseSourceDefendStance  = "defend_stance"
+	DefenseSourceDefenseBuff   = "defense_multiplier"
+	DefenseSourceIgnoreDefense = "ignore_defense"
+)
+
+// RoundEvent is a single, structured fact about a round's resolution. The
+// engine emits them in the order they happen; the human-readable
+// LastRoundSummary is derived from this list.
+type RoundEvent struct {
+	Type  RoundEventType `json:"type"`
+	Round int            `json:"round"`
+	// PlayerIndex/Player identify the acting player (or the owner of the
+	// hybrid for defeat, reserve and fatigue events).
+	PlayerIndex int    `json:"player_index"`
+	Player      string `json:"player"`
+	Hybrid      string `json:"hybrid,omitempty"`
+	// TargetIndex/Target identify the player on the receiving end of a
+	// debuff or attack. TargetIndex is nil when the event has no target.
+	TargetIndex  *int   `json:"target_index,omitempty"`
+	Target       string `json:"target,omitempty"`
+	TargetHybrid string `json:"target_hybrid,omitempty"`
+
+	Action   PendingActionType `json:"action,omitempty"`
+	Skill    string            `json:"skill,omitempty"`
+	SkillKey string            `json:"skill_key,omitempty"`
+
+	// Effect names the buff/debuff (see Effect* constants). Percent,
+	// Multiplier, Divisor and Duration describe its magnitude. On
+	// cost_paid events Percent is the ruleset modifier the cost triggered
+	// (Defend bonus, Vulnerable penalty or 0-VIG damage percentage).
+	Effect     string `json:"effect,omitempty"`
+	Percent    int    `json:"percent,omitempty"`
+	Multiplier int    `json:"multiplier,omitempty"`
+	Divisor    int    `json:"divisor,omitempty"`
+	Duration   int    `json:"duration,omitempty"`
+
+	// Energy and Vigor are amounts spent (cost_paid) or gained (rested,
+	// energy_restored). Defense is the DEF lost to fatigue.
+	Energy  int `json:"energy,omitempty"`
+	Vigor   int `json:"vigor,omitempty"`
+	Defense int `json:"defense,omitempty"`
+	// Amount is the HP lost (status_damage) or regained (healed).
+	Amount int `json:"amount,omitempty"`
+	// Vulnerable is set on cost_paid when an ability was used without
+	// enough VIG and the actor takes extra damage this round.
+	Vulnerable bool `json:"vulnerable,omitempty"`
+	// Reason explains an ability_failed event.
+	Reason string `json:"reason,omitempty"`
+	// Condition is set on events produced by a conditional skill effect
+	// and records the condition that held.
+	Condition *SkillCondition `json:"condition,omitempty"`
+
+	Damage *DamageBreakdown `json:"damage,omitempty"`
+}
+
+// DamageModifier is a multiplicative step applied to base damage.
+type DamageModifier struct {
+	Source string  `json:"source"`
+	Factor float64 `json:"factor"`
+}
+
+// DamageBreakdown records every input of a damage calculation so clients
+// can show (and analytics can aggregate) exactly how a hit was computed.
+type DamageBreakdown struct {
+	// Attack is the attacker's effective Attack, including AgilityBonus.
+	Attack int `json:"attack"`
+	// AgilityBonus is the Attack gained from agility-scaled damage.
+	AgilityBonus int `json:"agility_bonus,omitempty"`
+	// Defense is the target's effective Defense after penetration;
+	// DefenseIgnored is how much was ignored (IgnoredPercent of it).
+	Defense        int `json:"defense"`
+	DefenseIgnored int `json:"defense_ignored,omitempty"`
+	IgnoredPercent int `json:"ignored_percent,omitempty"`
+	// DefenseModifiers lists what altered the target's defense
+	// (defend stance, defense multiplier, ignored defense).
+	DefenseModifiers []string `json:"defense_modifiers,omitempty"`
+	Base             int      `json:"base"`
+	// Multipliers are applied to Base in order to obtain Final.
+	Multipliers []DamageModifier `json:"multipliers,omitempty"`
+	Final       int              `json:"final"`
+	// CriticalChance and DodgeChance are the percentages rolled for this
+	// hit (0 when no roll was made).
+	CriticalChance int `json:"critical_chance,omitempty"`
+	DodgeChance    int `json:"dodge_chance,omitempty"`
+}
+
+// HasModifier reports whether source appears in DefenseModifiers.
+func (d *DamageBreakdown) HasModifier(source string) bool {
+	for _, m := range d.DefenseModifiers {
+		if m == source {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMultiplier reports whether a multiplier with the given source was
+// applied.
+func (d *DamageBreakdown) HasMultiplier(source string) bool {
+	return d.Multiplier(source) != 0
+}
+
+// Multiplier returns the factor applied by the given source, or 0 when it
+// was not applied.
+func (d *DamageBreakdown) Multiplier(source string) float64 {
+	for _, m := range d.Multipliers {
+		if m.Source == source {
+			return m.Factor
+		}
+	}
+	return 0
+}
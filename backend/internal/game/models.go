@@ -28,6 +28,12 @@ type Entity struct {
 	// machine-readable parts). It is not persisted in the DB (gorm:"-")
 	// but will be exposed in API responses as a nested object.
 	Skill Skill `json:"skill" gorm:"-"`
+	// Passive is the entity's optional always-on trait (see Passive). It is
+	// configuration-driven and not persisted.
+	Passive *Passive `json:"passive,omitempty" gorm:"-"`
+	// Tags classify the entity (e.g. "predator") for synergy matching.
+	// Configuration-driven and not persisted.
+	Tags []string `json:"tags,omitempty" gorm:"-"`
 }
 
 // TableName overrides the default GORM table name for Entity so the
@@ -46,6 +52,8 @@ func (e *Entity) ApplyConfig(conf Entity) {
 	e.Energy = conf.Energy
 	e.VigorCost = conf.VigorCost
 	e.Skill = conf.Skill
+	e.Passive = conf.Passive
+	e.Tags = conf.Tags
 }
 
 // SkillEffect is a flexible description of what an entity's special ability
@@ -76,10 +84,46 @@ type SkillEffect struct {
 
 	// Instant effects
 	RestoreEnergy int `json:"restore_energy"`
-
-	// Swift pounce specific options
-	SwiftAddAgilityDivisor    int `json:"swift_add_agility_divisor"`
-	SwiftIgnoreDefensePercent int `json:"swift_ignore_defense_percent"`
+	// Heal restores HP to the user immediately (capped at base HP).
+	Heal int `json:"heal"`
+
+	// Over-time effects, resolved at the start of each of the following
+	// rounds: the opponent loses DamageOverTime HP per round (poison,
+	// bleed) and the user regains HealOverTime HP per round (capped at
+	// base HP).
+	DamageOverTime         int `json:"damage_over_time"`
+	DamageOverTimeDuration int `json:"damage_over_time_duration"`
+	HealOverTime           int `json:"heal_over_time"`
+	HealOverTimeDuration   int `json:"heal_over_time_duration"`
+
+	// Partial armor penetration: attacks made this round ignore the given
+	// percentage of the target's Defense (100 is equivalent to
+	// attack_ignores_defense).
+	IgnoreDefensePercent int `json:"ignore_defense_percent"`
+	// Agility-scaled damage: attacks made this round gain +1 Attack per
+	// AgilityDamageDivisor points of the user's (effective) Agility.
+	AgilityDamageDivisor int `json:"agility_damage_divisor"`
+
+	// When true the ability also strikes the opponent this round: an
+	// attack is executed in agility order with the ability's buffs applied.
+	Strike bool `json:"strike"`
+
+	// Stun: StunChance is the percentage chance that the opponent skips
+	// its next StunDuration round(s) (default 1). For striking abilities
+	// the roll happens when the strike lands. When StunResistPerAgility is
+	// set, every point of the target's effective Agility lowers the chance
+	// by that many percentage points.
+	StunChance           int `json:"stun_chance"`
+	StunDuration         int `json:"stun_duration"`
+	StunResistPerAgility int `json:"stun_resist_per_agility"`
+
+	// Stacking decides how the status effects applied by this skill combine
+	// with the same effects already applied by it (default "refresh").
+	Stacking StackingPolicy `json:"stacking"`
+
+	// Conditional effects are applied only when their condition holds
+	// against this round's revealed actions (see ConditionalEffect).
+	Conditional []ConditionalEffect `json:"conditional,omitempty"`
 
 	// Note: previously this struct included a few execution-specific
 	// parameters (priority, reveal, charge/stun execution flags). Those
@@ -87,6 +131,21 @@ type SkillEffect struct {
 	// fields describe pure buffs/debuffs and instant effects only.
 }
 
+// DefensePenetration returns the percentage (0–100) of the target's
+// Defense ignored by attacks made with this effect active.
+func (e SkillEffect) DefensePenetration() int {
+	if e.AttackIgnoresDefense {
+		return 100
+	}
+	if e.IgnoreDefensePercent < 0 {
+		return 0
+	}
+	if e.IgnoreDefensePercent > 100 {
+		return 100
+	}
+	return e.IgnoreDefensePercent
+}
+
 // Skill is a compact wrapper combining the human-readable metadata for an
 // ability (name, description, cost, key) with the structured machine
 // parameters contained in SkillEffect. Keep it non-persistent.
@@ -96,6 +155,9 @@ type Skill struct {
 	Cost        int         `json:"cost" gorm:"-"`
 	Key         string      `json:"key" gorm:"-"`
 	Effect      SkillEffect `json:"effect" gorm:"-"`
+	// Cooldown is the number of rounds after its use during which the
+	// ability cannot be used again (0 means no cooldown).
+	Cooldown int `json:"cooldown,omitempty" gorm:"-"`
 }
 
 type Hybrid struct {
@@ -130,23 +192,24 @@ type Hybrid struct {
 	SelectedAbilityEntityID *uint `json:"selected_ability_entity_id"`
 	IsActive                bool  `json:"is_active"`
 	IsDefeated              bool  `json:"is_defeated"`
-
-	StunnedUntilRound             int    `json:"stunned_until_round"`
-	AttackDebuffPercent           int    `json:"attack_debuff_percent"`
-	AttackDebuffUntilRound        int    `json:"attack_debuff_until_round"`
-	AttackBuffPercent             int    `json:"attack_buff_percent"`
-	AttackBuffUntilRound          int    `json:"attack_buff_until_round"`
-	DefenseBuffMultiplier         int    `json:"defense_buff_multiplier"`
-	DefenseBuffUntilRound         int    `json:"defense_buff_until_round"`
-	DefendStanceActive            bool   `json:"defend_stance_active"`
-	AgilityDebuffPercent          int    `json:"agility_debuff_percent"`
-	AgilityDebuffUntilRound       int    `json:"agility_debuff_until_round"`
-	CannotAttackUntilRound        int    `json:"cannot_attack_until_round"`
-	SelfDefenseIgnoredUntilRound  int    `json:"self_defense_ignored_until_round"`
-	LastAction                    string `json:"last_action"`
-	AttackHalvedThisRound         bool   `json:"attack_halved_this_round"`
-	VulnerableThisRound           bool   `json:"vulnerable_this_round"`
-	AttackIgnoresDefenseThisRound bool   `json:"attack_ignores_defense_this_round"`
+	// Revealed is set once the hybrid has entered the arena. A revealed
+	// hybrid that is switched out keeps its current HP/VIG/ENE and status
+	// effects; an unrevealed reserve enters with its base stats.
+	Revealed bool `json:"revealed"`
+	// Synergies are the combination bonuses the hybrid matched when it
+	// was built; flat stat bonuses are already part of its base stats.
+	Synergies []Synergy `json:"synergies" gorm:"serializer:json"`
+	// AbilityCooldowns maps the entity ID of an ability on cooldown to the
+	// rounds it must still wait, counting the current round.
+	AbilityCooldowns map[uint]int `json:"ability_cooldowns" gorm:"serializer:json"`
+
+	// StatusEffects holds the buffs, debuffs and control effects currently
+	// affecting the hybrid (see StatusEffect).
+	StatusEffects         []StatusEffect `json:"status_effects"`
+	DefendStanceActive    bool           `json:"defend_stance_active"`
+	LastAction            string         `json:"last_action"`
+	AttackHalvedThisRound bool           `json:"attack_halved_this_round"`
+	VulnerableThisRound   bool           `json:"vulnerable_this_round"`
 }
 
 type Player struct {
@@ -159,6 +222,22 @@ type Player struct {
 	HasSubmittedAction    bool              `json:"has_submitted_action"`
 	PendingActionType     PendingActionType `json:"pending_action_type"`
 	PendingActionEntityID *uint             `json:"pending_action_entity_id"`
+	// PendingSwitchIndex is the index (in Hybrids) of the reserve chosen
+	// by a pending `switch` action.
+	PendingSwitchIndex *int `json:"pending_switch_index"`
+	// NextReserveIndex is the reserve (index in Hybrids) the player wants
+	// to bring in when the active hybrid falls; nil (or an index that is
+	// no longer a valid reserve) picks the first one available.
+	NextReserveIndex *int `json:"next_reserve_index"`
+	// Team is the side the player fights for in ModeTeams games (see
+	// Game.TeamOf).
+	Team int `json:"team"`
+	// PendingTargetIndex is the enemy player (index in Game.Players) the
+	// pending action is aimed at; nil targets the first enemy in play.
+	PendingTargetIndex *int `json:"pending_target_index"`
+	// BotDifficulty is set for players controlled by the server (see
+	// package bot) and empty for humans.
+	BotDifficulty string `json:"bot_difficulty,omitempty"`
 }
 
 // Store per-game participants in a dedicated table for clarity
@@ -170,6 +249,8 @@ type Game struct {
 	Description      string     `json:"description" gorm:"size:256"`
 	Private          bool       `json:"private"`
 	JoinCode         string     `json:"join_code" gorm:"unique"`
+	HybridCount      int        `json:"hybrid_count"` // team size, see HybridsPerPlayer
+	Mode             GameMode   `json:"mode"`
 	Players          []Player   `json:"players"`
 	CurrentTurn      string     `json:"current_turn"`
 	RoundCount       int        `json:"round_count"`
@@ -177,9 +258,31 @@ type Game struct {
 	Phase            GamePhase  `json:"phase"` // planning | resolving
 	Status           GameStatus `json:"status"`
 	Winner           string     `json:"winner"`
+	WinningTeam      *int       `json:"winning_team"`
 	Message          string     `json:"message"`
 	LastRoundSummary string     `json:"last_round_summary"`
-	StatsCounted     bool       `json:"-"`
+	// LastRoundEvents is the structured log of the last resolved round.
+	// LastRoundSummary is derived from it; clients that animate or
+	// translate combat should consume this list instead.
+	LastRoundEvents []RoundEvent `json:"last_round_events" gorm:"serializer:json"`
+	StatsCounted    bool         `json:"-"`
+	// Draw is set when the match finished without a winner: the last
+	// hybrids of every side fell together and the tiebreak rule could not
+	// separate them (Winner is empty and WinningTeam nil).
+	Draw bool `json:"draw"`
+	// SuddenDeath is set once a simultaneous knockout sent the match to a
+	// sudden-death round (see TiebreakSuddenDeath).
+	SuddenDeath bool `json:"sudden_death"`
+	// RNGSeed is the root of every random decision the engine makes for
+	// this game (each round derives its own stream from it). It is
+	// assigned once when the match starts and persisted so any round can
+	// be re-resolved bit-for-bit from stored state and actions. Kept out
+	// of JSON so players cannot predict tie-breaks.
+	RNGSeed int64 `json:"-"`
+	// Rules is the ruleset recorded when the match started; every round
+	// is resolved with it (see engine.RulesFor). Nil for matches started
+	// before rules were recorded.
+	Rules *Rules `json:"-" gorm:"serializer:json"`
 	// ActionDeadline marks the time by which both players must submit their
 	// actions for the current planning phase. When empty/zero no deadline is
 	// enforced. Stored in the DB so it survives server restarts.
@@ -190,6 +293,45 @@ type Game struct {
 	ProcessingAt time.Time `json:"-" gorm:"index"`
 }
 
+// Team size limits for Game.HybridCount.
+const (
+	DefaultHybridCount = 2
+	MinHybridCount     = 1
+	MaxHybridCount     = 5
+)
+
+// HybridsPerPlayer returns the team size of the game (DefaultHybridCount
+// for games created before the setting existed).
+func (g *Game) HybridsPerPlayer() int {
+	if g.HybridCount <= 0 {
+		return DefaultHybridCount
+	}
+	return g.HybridCount
+}
+
+// IsBot reports whether the player is controlled by the server.
+func (p *Player) IsBot() bool { return p.BotDifficulty != "" }
+
+// HasBots reports whether any player of the game is a bot.
+func (g *Game) HasBots() bool {
+	for i := range g.Players {
+		if g.Players[i].IsBot() {
+			return true
+		}
+	}
+	return false
+}
+
+// AllDefeated reports whether every hybrid of the player has fallen.
+func (p *Player) AllDefeated() bool {
+	for i := range p.Hybrids {
+		if !p.Hybrids[i].IsDefeated {
+			return false
+		}
+	}
+	return len(p.Hybrids) > 0
+}
+
 // GameStatus and GamePhase provide typed aliases for the game state and
 // phase fields. Using typed constants reduces mistakes caused by raw
 // string literals throughout the codebase while keeping JSON
@@ -220,6 +362,7 @@ type User struct {
 	Email        string `gorm:"uniqueIndex"`
 	GamesPlayed  int
 	Wins         int
+	Draws        int
 	Resignations int
 }
 
@@ -237,6 +380,9 @@ const (
 	PendingActionAbility     PendingActionType = "ability"
 	PendingActionRest        PendingActionType = "rest"
 	PendingActionSkip        PendingActionType = "skip"
+	// PendingActionSwitch swaps the active hybrid with a reserve at the
+	// start of resolution (see Player.PendingSwitchIndex).
+	PendingActionSwitch PendingActionType = "switch"
 )
 
 // HybridGeneratedName stores AI-generated names for a canonical entity combination
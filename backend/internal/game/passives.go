@@ -0,0 +1,47 @@
+package game
+
+// Passive is an always-on trait of an entity. Unlike the Skill, which a
+// hybrid only gets from its selected entity, every base entity of a hybrid
+// contributes its passive. It is configured in chimera_config.json and not
+// persisted.
+type Passive struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Key         string        `json:"key"`
+	Effect      PassiveEffect `json:"effect"`
+}
+
+// PassiveEffect lists the supported passive traits. All fields are
+// optional; when several base entities provide the same trait their values
+// add up.
+type PassiveEffect struct {
+	// Thorns deals this much damage back to an attacker whenever it
+	// damages the hybrid.
+	Thorns int `json:"thorns"`
+	// VigorRegen restores extra VIG at the start of every round while the
+	// hybrid is active (capped at base VIG).
+	VigorRegen int `json:"vigor_regen"`
+	// DefendDamageReductionPercent reduces the damage taken while in the
+	// Defend stance by this percentage.
+	DefendDamageReductionPercent int `json:"defend_damage_reduction_percent"`
+	// BonusVsRestingPercent increases the damage dealt to a target that
+	// is resting this round by this percentage.
+	BonusVsRestingPercent int `json:"bonus_vs_resting_percent"`
+}
+
+// Passive trait names reported in passive_triggered events.
+const (
+	PassiveThorns     = "thorns"
+	PassiveVigorRegen = "vigor_regen"
+)
+
+// Passives returns the passives contributed by the hybrid's base entities.
+func (h *Hybrid) Passives() []Passive {
+	var out []Passive
+	for i := range h.BaseEntities {
+		if p := h.BaseEntities[i].Passive; p != nil {
+			out = append(out, *p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GameRound is the persisted history record of one resolved round. It is
+// written in the same transaction as the game update that resolved the
+// round, so the history always matches the stored game state.
+type GameRound struct {
+	gorm.Model
+	GameID      uint `json:"-" gorm:"uniqueIndex:idx_game_round_number"`
+	RoundNumber int  `json:"round_number" gorm:"uniqueIndex:idx_game_round_number"`
+	// Actions holds what each player chose for the round, in player order.
+	Actions []RoundAction `json:"actions" gorm:"serializer:json"`
+	// Pre and Post capture every hybrid's combat state right before and
+	// right after resolution.
+	Pre        []HybridSnapshot `json:"pre" gorm:"serializer:json"`
+	Post       []HybridSnapshot `json:"post" gorm:"serializer:json"`
+	Summary    string           `json:"summary"`
+	Events     []RoundEvent     `json:"events" gorm:"serializer:json"`
+	ResolvedAt time.Time        `json:"resolved_at"`
+}
+
+// Store round history in a dedicated table named after its parent.
+func (GameRound) TableName() string { return "game_rounds" }
+
+// RoundAction is the action a single player submitted for a round.
+type RoundAction struct {
+	PlayerIndex int               `json:"player_index"`
+	Player      string            `json:"player"`
+	ActionType  PendingActionType `json:"action_type"`
+	EntityID    *uint             `json:"entity_id,omitempty"`
+	// SwitchIndex is the reserve chosen by a `switch` action.
+	SwitchIndex *int `json:"switch_index,omitempty"`
+	// NextReserveIndex is the player's choice of the reserve to bring in
+	// if the active hybrid falls this round.
+	NextReserveIndex *int `json:"next_reserve_index,omitempty"`
+	// TargetIndex is the enemy player chosen as the action's target.
+	TargetIndex *int `json:"target_index,omitempty"`
+	// AutoRest is true when the player missed the deadline and the server
+	// submitted `rest` on their behalf.
+	AutoRest bool `json:"auto_rest,omitempty"`
+}
+
+// HybridSnapshot is a compact copy of a hybrid's combat state.
+type HybridSnapshot struct {
+	PlayerIndex int    `json:"player_index"`
+	HybridIndex int    `json:"hybrid_index"`
+	HybridID    uint   `json:"hybrid_id"`
+	Name        string `json:"name"`
+	HitPoints   int    `json:"current_pv"`
+	Attack      int    `json:"current_atq"`
+	Defense     int    `json:"current_def"`
+	Agility     int    `json:"current_agi"`
+	Energy      int    `json:"current_ene"`
+	Vigor       int    `json:"current_vig"`
+	IsActive    bool   `json:"is_active"`
+	IsDefeated  bool   `json:"is_defeated"`
+}
+
+// HybridSnapshots returns the current combat state of every hybrid in the
+// game, ordered by player then hybrid.
+func (g *Game) HybridSnapshots() []HybridSnapshot {
+	out := make([]HybridSnapshot, 0, 2*len(g.Players))
+	for pi := range g.Players {
+		for hi := range g.Players[pi].Hybrids {
+			h := &g.Players[pi].Hybrids[hi]
+			name := h.GeneratedName
+			if name == "" {
+				name = h.Name
+			}
+			out = append(out, HybridSnapshot{
+				PlayerIndex: pi,
+				HybridIndex: hi,
+				HybridID:    h.ID,
+				Name:        name,
+				HitPoints:   h.CurrentHitPoints,
+				Attack:      h.CurrentAttack,
+				Defense:     h.CurrentDefense,
+				Agility:     h.CurrentAgility,
+				Energy:      h.CurrentEnergy,
+				Vigor:       h.CurrentVIG,
+				IsActive:    h.IsActive,
+				IsDefeated:  h.IsDefeated,
+			})
+		}
+	}
+	return out
+}
+
+// PendingRoundActions returns the actions currently submitted by each
+// player. autoRest lists the players (if any; -1 is ignored) whose action
+// was auto-submitted after a timeout.
+func (g *Game) PendingRoundActions(autoRest ...int) []RoundAction {
+	out := make([]RoundAction, len(g.Players))
+	for i := range g.Players {
+		p := &g.Players[i]
+		var eid *uint
+		if p.PendingActionEntityID != nil {
+			v := *p.PendingActionEntityID
+			eid = &v
+		}
+		var sw, next, target *int
+		if p.PendingSwitchIndex != nil {
+			v := *p.PendingSwitchIndex
+			sw = &v
+		}
+		if p.NextReserveIndex != nil {
+			v := *p.NextReserveIndex
+			next = &v
+		}
+		if p.PendingTargetIndex != nil {
+			v := *p.PendingTargetIndex
+			target = &v
+		}
+		auto := false
+		for _, a := range autoRest {
+			auto = auto || a == i
+		}
+		out[i] = RoundAction{
+			PlayerIndex:      i,
+			Player:           p.PlayerName,
+			ActionType:       p.PendingActionType,
+			EntityID:         eid,
+			SwitchIndex:      sw,
+			NextReserveIndex: next,
+			TargetIndex:      target,
+			AutoRest:         auto,
+		}
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rules holds the core combat numbers used by the engine. They are loaded
+// from the `rules` section of chimera_config.json; omitted fields keep the
+// values returned by DefaultRules.
+type Rules struct {
+	// EnergyPerRound is the Energy the active hybrid regenerates at the
+	// start of every round.
+	EnergyPerRound int `json:"energy_per_round"`
+	// RestVigor and RestEnergy are restored by the Rest action (Vigor is
+	// capped at the hybrid's base VIG).
+	RestVigor  int `json:"rest_vigor"`
+	RestEnergy int `json:"rest_energy"`
+	// DefendMultiplier multiplies Defense while the Defend stance is
+	// active.
+	DefendMultiplier float64 `json:"defend_multiplier"`
+	// VulnerableMultiplier multiplies damage taken by a hybrid that used
+	// an ability without enough Vigor.
+	VulnerableMultiplier float64 `json:"vulnerable_multiplier"`
+	// ZeroVigorMultiplier multiplies the damage of a basic attack made
+	// with 0 VIG.
+	ZeroVigorMultiplier float64 `json:"zero_vigor_multiplier"`
+	// BaseVigor is the starting (and maximum) Vigor of every hybrid.
+	BaseVigor int `json:"base_vigor"`
+	// Fatigue is the battle fatigue schedule.
+	Fatigue FatigueRules `json:"fatigue"`
+	// Critical and Dodge are the agility-driven critical hit and evasion
+	// rolls made for every attack.
+	Critical CriticalRules `json:"critical"`
+	Dodge    ChanceRules   `json:"dodge"`
+	// Abilities decides which base entities' skills a hybrid may use.
+	Abilities AbilityRules `json:"abilities"`
+	// Tiebreak settles rounds in which the last hybrids of every side fall
+	// together.
+	Tiebreak Tiebreak `json:"tiebreak"`
+}
+
+// Tiebreak names the rule applied to a simultaneous knockout. Rules that
+// compare the sides fall back to a draw when the sides are even.
+type Tiebreak string
+
+const (
+	// TiebreakNone makes a simultaneous knockout a draw.
+	TiebreakNone Tiebreak = "none"
+	// TiebreakRemainingHP gives the match to the side whose fallen
+	// hybrids had more HP left at the start of the round.
+	TiebreakRemainingHP Tiebreak = "remaining_hp"
+	// TiebreakAgility gives the match to the side whose fallen hybrids
+	// had more Agility (status modifiers included).
+	TiebreakAgility Tiebreak = "agility"
+	// TiebreakSuddenDeath brings the fallen hybrids back with 1 HP and no
+	// status effects for one more round. The side left standing after it
+	// wins; if both or neither fall, the match is a draw.
+	TiebreakSuddenDeath Tiebreak = "sudden_death"
+)
+
+// AbilityRules configures ability usage. By default a hybrid can only use
+// the skill of the entity selected at creation (its primary ability);
+// with AllUsable every base entity's skill is usable and non-primary
+// skills cost SecondaryEnergySurcharge extra Energy.
+type AbilityRules struct {
+	AllUsable                bool `json:"all_usable"`
+	SecondaryEnergySurcharge int  `json:"secondary_energy_surcharge"`
+}
+
+// ChanceRules turns an Agility lead into a percentage chance: BaseChance
+// plus ChancePerAgility for every point of lead, capped at MaxChance.
+type ChanceRules struct {
+	BaseChance       int `json:"base_chance"`
+	ChancePerAgility int `json:"chance_per_agility"`
+	MaxChance        int `json:"max_chance"`
+}
+
+// Chance returns the percentage chance for the given Agility lead
+// (negative leads count as zero).
+func (c ChanceRules) Chance(lead int) int {
+	if lead < 0 {
+		lead = 0
+	}
+	p := c.BaseChance + c.ChancePerAgility*lead
+	if p > c.MaxChance {
+		p = c.MaxChance
+	}
+	if p < 0 {
+		p = 0
+	}
+	return p
+}
+
+func (c ChanceRules) validate(name string) error {
+	switch {
+	case c.BaseChance < 0 || c.BaseChance > 100:
+		return fmt.Errorf("%s.base_chance must be in [0, 100]", name)
+	case c.ChancePerAgility < 0:
+		return fmt.Errorf("%s.chance_per_agility must be >= 0", name)
+	case c.MaxChance < 0 || c.MaxChance > 100:
+		return fmt.Errorf("%s.max_chance must be in [0, 100]", name)
+	}
+	return nil
+}
+
+// CriticalRules configures critical hits: the attacker's Agility lead over
+// the target gives the chance, and a critical hit multiplies damage by
+// Multiplier.
+type CriticalRules struct {
+	ChanceRules
+	Multiplier float64 `json:"multiplier"`
+}
+
+// FatigueRules describes battle fatigue: starting at StartRound, the
+// active hybrids lose DefenseLoss[i] DEF at the start of round
+// StartRound+i. The last entry repeats for every later round; an empty
+// schedule disables fatigue.
+type FatigueRules struct {
+	StartRound  int   `json:"start_round"`
+	DefenseLoss []int `json:"defense_loss"`
+}
+
+// DefaultRules returns the standard ruleset described in game.md.
+func DefaultRules() Rules {
+	return Rules{
+		EnergyPerRound:       1,
+		RestVigor:            2,
+		RestEnergy:           2,
+		DefendMultiplier:     1.5,
+		VulnerableMultiplier: 1.25,
+		ZeroVigorMultiplier:  0.5,
+		BaseVigor:            3,
+		Fatigue:              FatigueRules{StartRound: 3, DefenseLoss: []int{1, 2, 3}},
+		Critical: CriticalRules{
+			ChanceRules: ChanceRules{ChancePerAgility: 5, MaxChance: 40},
+			Multiplier:  1.5,
+		},
+		Dodge:     ChanceRules{ChancePerAgility: 4, MaxChance: 30},
+		Abilities: AbilityRules{SecondaryEnergySurcharge: 1},
+		Tiebreak:  TiebreakNone,
+	}
+}
+
+// FatigueLoss returns the DEF lost to fatigue at the start of round.
+func (r Rules) FatigueLoss(round int) int {
+	n := len(r.Fatigue.DefenseLoss)
+	if n == 0 || round < r.Fatigue.StartRound {
+		return 0
+	}
+	i := round - r.Fatigue.StartRound
+	if i >= n {
+		i = n - 1
+	}
+	return r.Fatigue.DefenseLoss[i]
+}
+
+// Validate reports the first rule that is out of range.
+func (r Rules) Validate() error {
+	switch {
+	case r.EnergyPerRound < 0:
+		return errors.New("energy_per_round must be >= 0")
+	case r.RestVigor < 0:
+		return errors.New("rest_vigor must be >= 0")
+	case r.RestEnergy < 0:
+		return errors.New("rest_energy must be >= 0")
+	case r.DefendMultiplier < 1:
+		return errors.New("defend_multiplier must be >= 1")
+	case r.VulnerableMultiplier < 1:
+		return errors.New("vulnerable_multiplier must be >= 1")
+	case r.ZeroVigorMultiplier <= 0 || r.ZeroVigorMultiplier > 1:
+		return errors.New("zero_vigor_multiplier must be in (0, 1]")
+	case r.BaseVigor < 1:
+		return errors.New("base_vigor must be >= 1")
+	case r.Fatigue.StartRound < 1:
+		return errors.New("fatigue.start_round must be >= 1")
+	case r.Critical.Multiplier < 1:
+		return errors.New("critical.multiplier must be >= 1")
+	case r.Abilities.SecondaryEnergySurcharge < 0:
+		return errors.New("abilities.secondary_energy_surcharge must be >= 0")
+	}
+	switch r.Tiebreak {
+	case "", TiebreakNone, TiebreakRemainingHP, TiebreakAgility, TiebreakSuddenDeath:
+	default:
+		return fmt.Errorf("tiebreak must be one of none, remaining_hp, agility or sudden_death, got %q", r.Tiebreak)
+	}
+	if err := r.Critical.validate("critical"); err != nil {
+		return err
+	}
+	if err := r.Dodge.validate("dodge"); err != nil {
+		return err
+	}
+	for i, d := range r.Fatigue.DefenseLoss {
+		if d < 0 {
+			return fmt.Errorf("fatigue.defense_loss[%d] must be >= 0", i)
+		}
+	}
+	return nil
+}
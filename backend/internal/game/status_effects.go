@@ -0,0 +1,126 @@
+package game
+
+import "gorm.io/gorm"
+
+// StatusEffectKind identifies what a StatusEffect does. Kinds share their
+// names with the Effect* values reported in round events.
+type StatusEffectKind string
+
+const (
+	// StatusAttackBuff raises Attack by Magnitude percent.
+	StatusAttackBuff StatusEffectKind = EffectAttackBuff
+	// StatusAttackDebuff lowers Attack by Magnitude percent.
+	StatusAttackDebuff StatusEffectKind = EffectAttackDebuff
+	// StatusAgilityDebuff lowers Agility by Magnitude percent.
+	StatusAgilityDebuff StatusEffectKind = EffectAgilityDebuff
+	// StatusDefenseMultiplier multiplies Defense by Magnitude.
+	StatusDefenseMultiplier StatusEffectKind = EffectDefenseMultiplier
+	// StatusIgnoreDefense makes attacks ignore Magnitude percent of the
+	// target's Defense.
+	StatusIgnoreDefense StatusEffectKind = EffectIgnoreDefense
+	// StatusAgilityDamage adds +1 Attack per Magnitude points of Agility.
+	StatusAgilityDamage StatusEffectKind = EffectAgilityDamage
+	// StatusCannotAttack prevents the hybrid from attacking.
+	StatusCannotAttack StatusEffectKind = EffectCannotAttack
+	// StatusStun makes the hybrid skip its action.
+	StatusStun StatusEffectKind = EffectStun
+	// StatusDamageOverTime deals Magnitude damage at the start of each
+	// round.
+	StatusDamageOverTime StatusEffectKind = EffectDamageOverTime
+	// StatusHealOverTime restores Magnitude HP at the start of each round.
+	StatusHealOverTime StatusEffectKind = EffectHealOverTime
+	// StatusDamageBonus raises the damage of the hybrid's attacks by
+	// Magnitude percent.
+	StatusDamageBonus StatusEffectKind = EffectDamageBonus
+	// StatusReflect returns Magnitude percent of the damage the hybrid
+	// takes to the attacker.
+	StatusReflect StatusEffectKind = EffectReflect
+)
+
+// StackingPolicy decides what happens when an effect is applied to a
+// hybrid that already carries an effect of the same kind from the same
+// source skill.
+type StackingPolicy string
+
+const (
+	// StackRefresh replaces the existing effect's magnitude and duration
+	// (the default).
+	StackRefresh StackingPolicy = "refresh"
+	// StackExtend keeps the stronger magnitude and adds the durations.
+	StackExtend StackingPolicy = "extend"
+	// StackIndependent adds a separate instance; magnitudes combine.
+	StackIndependent StackingPolicy = "stack"
+)
+
+// StatusEffect is a temporary modifier carried by a hybrid. It is active
+// from StartRound while RemainingRounds is positive; the engine decrements
+// RemainingRounds at the end of every round in which it was active and
+// drops it when it reaches zero.
+type StatusEffect struct {
+	gorm.Model
+	HybridID  uint             `json:"-" gorm:"index"`
+	Kind      StatusEffectKind `json:"kind"`
+	Magnitude int              `json:"magnitude"`
+	// SourceSkill and SourceName are the key and display name of the
+	// skill that applied the effect.
+	SourceSkill     string         `json:"source_skill,omitempty"`
+	SourceName      string         `json:"source_name,omitempty"`
+	StartRound      int            `json:"start_round"`
+	RemainingRounds int            `json:"remaining_rounds"`
+	Stacking        StackingPolicy `json:"stacking"`
+}
+
+// Store status effects in a dedicated table named after their owner.
+func (StatusEffect) TableName() string { return "hybrid_status_effects" }
+
+// ActiveIn reports whether the effect applies during round.
+func (e *StatusEffect) ActiveIn(round int) bool {
+	return e.RemainingRounds > 0 && e.StartRound <= round
+}
+
+// AddStatusEffect applies e to the hybrid honoring e.Stacking against an
+// existing effect of the same kind and source.
+func (h *Hybrid) AddStatusEffect(e StatusEffect) {
+	if e.Stacking == "" {
+		e.Stacking = StackRefresh
+	}
+	if e.Stacking != StackIndependent {
+		for i := range h.StatusEffects {
+			cur := &h.StatusEffects[i]
+			if cur.Kind != e.Kind || cur.SourceSkill != e.SourceSkill || cur.RemainingRounds <= 0 {
+				continue
+			}
+			if e.Stacking == StackExtend {
+				if e.Magnitude > cur.Magnitude {
+					cur.Magnitude = e.Magnitude
+				}
+				cur.RemainingRounds += e.RemainingRounds
+			} else {
+				cur.Magnitude = e.Magnitude
+				cur.StartRound = e.StartRound
+				cur.RemainingRounds = e.RemainingRounds
+			}
+			cur.Stacking = e.Stacking
+			return
+		}
+	}
+	h.StatusEffects = append(h.StatusEffects, e)
+}
+
+// ActiveEffects returns the hybrid's effects of the given kind that apply
+// during round.
+func (h *Hybrid) ActiveEffects(kind StatusEffectKind, round int) []StatusEffect {
+	var out []StatusEffect
+	for i := range h.StatusEffects {
+		if h.StatusEffects[i].Kind == kind && h.StatusEffects[i].ActiveIn(round) {
+			out = append(out, h.StatusEffects[i])
+		}
+	}
+	return out
+}
+
+// HasStatus reports whether an effect of the given kind applies during
+// round.
+func (h *Hybrid) HasStatus(kind StatusEffectKind, round int) bool {
+	return len(h.ActiveEffects(kind, round)) > 0
+}
@@ -0,0 +1,88 @@
+package game
+
+import "strings"
+
+// Synergy is a bonus granted to hybrids whose base entities form a given
+// combination. A synergy matches when every entity named in Entities is
+// part of the hybrid and, when Tag is set, at least MinCount of its base
+// entities carry that tag. Synergies are configured in chimera_config.json;
+// the ones a hybrid matched are stored on it at creation time.
+type Synergy struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Key         string       `json:"key"`
+	Entities    []string     `json:"entities,omitempty"`
+	Tag         string       `json:"tag,omitempty"`
+	MinCount    int          `json:"min_count,omitempty"`
+	Bonus       SynergyBonus `json:"bonus"`
+}
+
+// SynergyBonus lists what a synergy grants. Flat stat bonuses are added to
+// the hybrid's base stats when it is built; per-round bonuses are applied
+// by the engine at the start of every round while the hybrid is active.
+type SynergyBonus struct {
+	HitPoints int `json:"hit_points"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	Agility   int `json:"agility"`
+	Energy    int `json:"energy"`
+
+	DefensePerRound int `json:"defense_per_round"`
+	EnergyPerRound  int `json:"energy_per_round"`
+}
+
+// Matches reports whether a hybrid made of entities qualifies for s.
+func (s Synergy) Matches(entities []Entity) bool {
+	if len(s.Entities) == 0 && s.Tag == "" {
+		return false
+	}
+	for _, name := range s.Entities {
+		found := false
+		for i := range entities {
+			if strings.EqualFold(entities[i].Name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Tag != "" {
+		n := 0
+		for i := range entities {
+			if entities[i].HasTag(s.Tag) {
+				n++
+			}
+		}
+		min := s.MinCount
+		if min <= 0 {
+			min = 2
+		}
+		if n < min {
+			return false
+		}
+	}
+	return true
+}
+
+// Involves reports whether e can contribute to s (it is named by the
+// synergy or carries its tag).
+func (s Synergy) Involves(e Entity) bool {
+	for _, name := range s.Entities {
+		if strings.EqualFold(e.Name, name) {
+			return true
+		}
+	}
+	return s.Tag != "" && e.HasTag(s.Tag)
+}
+
+// HasTag reports whether the entity carries tag (case-insensitive).
+func (e *Entity) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package game
+
+// GameMode decides how many players take part in a match and how they are
+// grouped. Every mode has two opposing teams.
+type GameMode string
+
+const (
+	// ModeDuel is one player against another (the default).
+	ModeDuel GameMode = "duel"
+	// ModeTeams is two teams of two players, each controlling one active
+	// hybrid.
+	ModeTeams GameMode = "teams"
+)
+
+// TeamCount is the number of opposing sides in every match.
+const TeamCount = 2
+
+// Valid reports whether m is a known mode ("" is read as ModeDuel).
+func (m GameMode) Valid() bool {
+	return m == "" || m == ModeDuel || m == ModeTeams
+}
+
+// MaxPlayers returns how many players the game seats.
+func (g *Game) MaxPlayers() int {
+	if g.Mode == ModeTeams {
+		return 2 * TeamCount
+	}
+	return TeamCount
+}
+
+// NextTeam returns the team of the next player to join: the side with the
+// fewest players (the first one on a tie), so teams stay balanced even
+// after someone leaves the waiting room.
+func (g *Game) NextTeam() int {
+	var count [TeamCount]int
+	for i := range g.Players {
+		if t := g.Players[i].Team; t >= 0 && t < TeamCount {
+			count[t]++
+		}
+	}
+	best := 0
+	for t := 1; t < TeamCount; t++ {
+		if count[t] < count[best] {
+			best = t
+		}
+	}
+	return best
+}
+
+// TeamOf returns the team of the player at index i. Duel players are
+// their own team, which also covers games stored before teams existed.
+func (g *Game) TeamOf(i int) int {
+	if g.Mode != ModeTeams {
+		return i
+	}
+	return g.Players[i].Team
+}
+
+// TeamPlayers returns the indexes of the players on team t.
+func (g *Game) TeamPlayers(t int) []int {
+	var out []int
+	for i := range g.Players {
+		if g.TeamOf(i) == t {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// TeamDefeated reports whether every player on team t has lost all of
+// their hybrids.
+func (g *Game) TeamDefeated(t int) bool {
+	for _, i := range g.TeamPlayers(t) {
+		if !g.Players[i].AllDefeated() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsWinner reports whether the player at index i is on the winning side.
+// Games finished before teams existed only record the winner's name.
+func (g *Game) IsWinner(i int) bool {
+	if g.WinningTeam != nil {
+		return g.TeamOf(i) == *g.WinningTeam
+	}
+	return g.Winner != "" && g.Players[i].PlayerName == g.Winner
+}
+
+// AllCreated reports whether every player has created their hybrids.
+func (g *Game) AllCreated() bool {
+	for i := range g.Players {
+		if !g.Players[i].HasCreated {
+			return false
+		}
+	}
+	return len(g.Players) > 0
+}
+
+// AllSubmitted reports whether every player has an action for the round
+// (players out of the match are submitted as a skip by the engine).
+func (g *Game) AllSubmitted() bool {
+	for i := range g.Players {
+		if !g.Players[i].HasSubmittedAction {
+			return false
+		}
+	}
+	return len(g.Players) > 0
+}
@@ -0,0 +1,221 @@
+// Package replay serializes finished matches into self-contained, versioned
+// replay files and re-simulates them through the engine to check that the
+// recorded outcome can be reproduced.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// FormatVersion is the current replay file format. Bump it whenever the
+// JSON layout changes in a way older readers cannot handle.
+const FormatVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported replay format version")
+	ErrNoPlayers          = errors.New("replay has no players")
+)
+
+// Replay is everything needed to re-run a match: the entity definitions and
+// combat rules in effect when it was played, the hybrids each player built,
+// the RNG seed and the actions submitted each round (with the recorded
+// outcome).
+type Replay struct {
+	Version    int       `json:"version"`
+	JoinCode   string    `json:"join_code"`
+	ExportedAt time.Time `json:"exported_at"`
+	Seed       int64     `json:"seed"`
+	Entities   []Entity  `json:"entities"`
+	// Rules is the ruleset the match was resolved with (the rules in
+	// effect at export time for matches started before rules were
+	// recorded on the game). Replays exported before rules were
+	// configurable omit it and use the current rules.
+	Rules *game.Rules `json:"rules,omitempty"`
+	// Mode is the game mode; duel replays omit it.
+	Mode    game.GameMode `json:"mode,omitempty"`
+	Players []PlayerSetup `json:"players"`
+	Rounds  []Round       `json:"rounds"`
+	Result  Result        `json:"result"`
+}
+
+// Entity is the configuration snapshot of an entity used in the match.
+type Entity struct {
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	HitPoints int        `json:"hit_points"`
+	Attack    int        `json:"attack"`
+	Defense   int        `json:"defense"`
+	Agility   int        `json:"agility"`
+	Energy    int        `json:"energy"`
+	VigorCost int        `json:"vigor_cost"`
+	Skill     game.Skill `json:"skill"`
+	// Passive is omitted for entities without one.
+	Passive *game.Passive `json:"passive,omitempty"`
+}
+
+// PlayerSetup lists a player's hybrids in the order they were created.
+type PlayerSetup struct {
+	Name string `json:"name"`
+	// Team is the player's side in team matches.
+	Team    int           `json:"team,omitempty"`
+	Hybrids []HybridSetup `json:"hybrids"`
+}
+
+// HybridSetup is a hybrid as it was built during the creation phase.
+type HybridSetup struct {
+	Name             string `json:"name"`
+	GeneratedName    string `json:"generated_name,omitempty"`
+	EntityIDs        []uint `json:"entity_ids"`
+	SelectedEntityID *uint  `json:"selected_entity_id,omitempty"`
+	BaseHitPoints    int    `json:"base_pv"`
+	BaseAttack       int    `json:"base_atq"`
+	BaseDefense      int    `json:"base_def"`
+	BaseAgility      int    `json:"base_agi"`
+	BaseEnergy       int    `json:"base_ene"`
+	BaseVIG          int    `json:"base_vig"`
+	// Synergies matched at creation (their flat bonuses are already part
+	// of the base stats above).
+	Synergies []game.Synergy `json:"synergies,omitempty"`
+}
+
+// Round is one recorded round: the submitted actions and the state every
+// hybrid was left in afterwards.
+type Round struct {
+	Number  int                   `json:"number"`
+	Actions []game.RoundAction    `json:"actions"`
+	Post    []game.HybridSnapshot `json:"post"`
+	Summary string                `json:"summary,omitempty"`
+}
+
+// Result is the recorded end state of the match.
+type Result struct {
+	Status  game.GameStatus `json:"status"`
+	Winner  string          `json:"winner"`
+	Draw    bool            `json:"draw,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// Build creates a replay from a fully loaded game (players, hybrids and
+// base entities with config applied) and its recorded round history.
+func Build(g *game.Game, rounds []game.GameRound) *Replay {
+	rules := engine.RulesFor(g)
+	r := &Replay{
+		Version:    FormatVersion,
+		JoinCode:   g.JoinCode,
+		ExportedAt: time.Now().UTC(),
+		Seed:       g.RNGSeed,
+		Mode:       teamMode(g.Mode),
+		Rules:      &rules,
+		Result:     Result{Status: g.Status, Winner: g.Winner, Draw: g.Draw, Message: g.Message},
+	}
+
+	seen := map[uint]bool{}
+	for _, p := range g.Players {
+		ps := PlayerSetup{Name: p.PlayerName, Team: p.Team}
+		for _, h := range p.Hybrids {
+			hs := HybridSetup{
+				Name:          h.Name,
+				GeneratedName: h.GeneratedName,
+				BaseHitPoints: h.BaseHitPoints,
+				BaseAttack:    h.BaseAttack,
+				BaseDefense:   h.BaseDefense,
+				BaseAgility:   h.BaseAgility,
+				BaseEnergy:    h.BaseEnergy,
+				BaseVIG:       h.BaseVIG,
+				Synergies:     h.Synergies,
+			}
+			if h.SelectedAbilityEntityID != nil {
+				sel := *h.SelectedAbilityEntityID
+				hs.SelectedEntityID = &sel
+			}
+			for _, e := range h.BaseEntities {
+				hs.EntityIDs = append(hs.EntityIDs, e.ID)
+				if !seen[e.ID] {
+					seen[e.ID] = true
+					r.Entities = append(r.Entities, entityFromGame(e))
+				}
+			}
+			ps.Hybrids = append(ps.Hybrids, hs)
+		}
+		r.Players = append(r.Players, ps)
+	}
+
+	for _, gr := range rounds {
+		r.Rounds = append(r.Rounds, Round{
+			Number:  gr.RoundNumber,
+			Actions: gr.Actions,
+			Post:    gr.Post,
+			Summary: gr.Summary,
+		})
+	}
+	return r
+}
+
+// teamMode returns m for team games and "" for duels, which replays
+// leave implicit.
+func teamMode(m game.GameMode) game.GameMode {
+	if m == game.ModeTeams {
+		return m
+	}
+	return ""
+}
+
+// Encode writes the replay as indented JSON.
+func Encode(w io.Writer, r *Replay) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Decode reads a replay and checks that its format version is supported.
+func Decode(rd io.Reader) (*Replay, error) {
+	var r Replay
+	if err := json.NewDecoder(rd).Decode(&r); err != nil {
+		return nil, fmt.Errorf("failed to parse replay: %w", err)
+	}
+	if r.Version < 1 || r.Version > FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, r.Version)
+	}
+	if len(r.Players) == 0 {
+		return nil, ErrNoPlayers
+	}
+	return &r, nil
+}
+
+func entityFromGame(e game.Entity) Entity {
+	return Entity{
+		ID:        e.ID,
+		Name:      e.Name,
+		HitPoints: e.HitPoints,
+		Attack:    e.Attack,
+		Defense:   e.Defense,
+		Agility:   e.Agility,
+		Energy:    e.Energy,
+		VigorCost: e.VigorCost,
+		Skill:     e.Skill,
+		Passive:   e.Passive,
+	}
+}
+
+func (e Entity) toGame() game.Entity {
+	ge := game.Entity{
+		Name:      e.Name,
+		HitPoints: e.HitPoints,
+		Attack:    e.Attack,
+		Defense:   e.Defense,
+		Agility:   e.Agility,
+		Energy:    e.Energy,
+		VigorCost: e.VigorCost,
+		Skill:     e.Skill,
+		Passive:   e.Passive,
+	}
+	ge.ID = e.ID
+	return ge
+}
@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func testEntities() []game.Entity {
+	mk := func(id uint, name string, hp, atk, def, agi, ene int) game.Entity {
+		e := game.Entity{Name: name, HitPoints: hp, Attack: atk, Defense: def, Agility: agi, Energy: ene, VigorCost: 1}
+		e.ID = id
+		return e
+	}
+	return []game.Entity{
+		mk(1, "Lion", 8, 5, 2, 4, 1),
+		mk(2, "Raven", 4, 2, 1, 6, 1),
+		mk(3, "Turtle", 10, 2, 5, 1, 1),
+		mk(4, "Wolf", 6, 4, 2, 5, 1),
+	}
+}
+
+// playMatch plays basic attacks until the match ends and records every
+// round the way the service layer does.
+func playMatch(t *testing.T) (*game.Game, []game.GameRound) {
+	t.Helper()
+	ents := testEntities()
+	g := &game.Game{JoinCode: "ABC123", RNGSeed: 42}
+	g.Players = []game.Player{
+		{PlayerName: "P1", Hybrids: []game.Hybrid{engine.BuildHybrid(ents[0:2], 1), engine.BuildHybrid(ents[2:4], 3)}},
+		{PlayerName: "P2", Hybrids: []game.Hybrid{engine.BuildHybrid(ents[2:4], 4), engine.BuildHybrid(ents[0:2], 2)}},
+	}
+	engine.PrepareMatch(g)
+
+	var rounds []game.GameRound
+	for g.Status == game.StatusInProgress && g.RoundCount < 100 {
+		for i := range g.Players {
+			g.Players[i].PendingActionType = game.PendingActionBasicAttack
+			g.Players[i].HasSubmittedAction = true
+		}
+		r := game.GameRound{RoundNumber: g.RoundCount, Actions: g.PendingRoundActions(-1), Pre: g.HybridSnapshots()}
+		engine.ResolveRound(g)
+		r.Post = g.HybridSnapshots()
+		r.Summary = g.LastRoundSummary
+		r.ResolvedAt = time.Now()
+		rounds = append(rounds, r)
+	}
+	if g.Status != game.StatusFinished {
+		t.Fatalf("expected the match to finish, status=%s", g.Status)
+	}
+	return g, rounds
+}
+
+func TestReplay_RoundTripReproducesMatch(t *testing.T) {
+	g, rounds := playMatch(t)
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, Build(g, rounds)); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	r, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	rep, err := Simulate(r, nil)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if !rep.Matches() {
+		t.Fatalf("expected no divergences, got %v", rep.Divergences)
+	}
+	if rep.Winner != g.Winner || rep.RoundsSimulated != len(rounds) {
+		t.Fatalf("unexpected report: %+v", rep)
+	}
+}
+
+func TestReplay_UsesRulesRecordedOnTheGame(t *testing.T) {
+	recorded := game.DefaultRules()
+	recorded.BaseVigor = 7
+	prev := engine.CurrentRules()
+	engine.SetRules(recorded)
+	g, rounds := playMatch(t)
+	g.Rules = &recorded
+
+	// The configuration changes after the match was played.
+	changed := game.DefaultRules()
+	changed.BaseVigor = 1
+	engine.SetRules(changed)
+	t.Cleanup(func() { engine.SetRules(prev) })
+
+	r := Build(g, rounds)
+	if r.Rules == nil || r.Rules.BaseVigor != 7 {
+		t.Fatalf("expected the recorded rules in the replay, got %+v", r.Rules)
+	}
+	rep, err := Simulate(r, nil)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if !rep.Matches() {
+		t.Fatalf("expected no divergences under the recorded rules, got %v", rep.Divergences)
+	}
+}
+
+func TestReplay_ReportsDivergence(t *testing.T) {
+	g, rounds := playMatch(t)
+	r := Build(g, rounds)
+	r.Rounds[0].Post[1].HitPoints += 3
+
+	rep, err := Simulate(r, nil)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if len(rep.Divergences) != 1 {
+		t.Fatalf("expected exactly one divergence, got %v", rep.Divergences)
+	}
+	if d := rep.Divergences[0]; d.Round != 1 || d.What != "player 1 hybrid 2 current_pv" {
+		t.Fatalf("unexpected divergence: %s", d)
+	}
+
+	// Changing the rules of the match (a stronger Wolf) changes the outcome.
+	over := testEntities()
+	over[3].Attack = 40
+	rep, err = Simulate(Build(g, rounds), &Overrides{Entities: over})
+	if err != nil {
+		t.Fatalf("simulate with overrides: %v", err)
+	}
+	if rep.Matches() {
+		t.Fatalf("expected overrides to diverge from the recorded match")
+	}
+}
+
+func TestDecode_RejectsUnknownVersion(t *testing.T) {
+	_, err := Decode(bytes.NewBufferString(`{"version": 99, "players": [{"name": "P1"}]}`))
+	if err == nil {
+		t.Fatalf("expected an error for an unsupported version")
+	}
+}
@@ -0,0 +1,252 @@
+package replay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// Divergence is a single difference between the recorded match and the
+// state recomputed by the engine.
+type Divergence struct {
+	Round      int    `json:"round"`
+	What       string `json:"what"`
+	Recorded   string `json:"recorded"`
+	Recomputed string `json:"recomputed"`
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("round %d: %s: recorded %s, recomputed %s", d.Round, d.What, d.Recorded, d.Recomputed)
+}
+
+// Report summarizes a re-simulation.
+type Report struct {
+	RoundsSimulated int             `json:"rounds_simulated"`
+	Status          game.GameStatus `json:"status"`
+	Winner          string          `json:"winner"`
+	Draw            bool            `json:"draw"`
+	Divergences     []Divergence    `json:"divergences"`
+}
+
+// Matches reports whether the recomputed match is identical to the
+// recorded one.
+func (r *Report) Matches() bool { return len(r.Divergences) == 0 }
+
+// Overrides replaces parts of a replay's recorded configuration, which is
+// useful to evaluate a match under a new config.
+type Overrides struct {
+	// Entities, when non-nil, are looked up by name and hybrids are
+	// rebuilt from their stats instead of the recorded base stats.
+	Entities []game.Entity
+	// Rules, when non-nil, replaces the recorded ruleset.
+	Rules *game.Rules
+	// Synergies are matched when hybrids are rebuilt from Entities.
+	Synergies []game.Synergy
+}
+
+// rules returns the ruleset to re-simulate the replay with.
+func (r *Replay) rules(o *Overrides) game.Rules {
+	switch {
+	case o != nil && o.Rules != nil:
+		return *o.Rules
+	case r.Rules != nil:
+		return *r.Rules
+	}
+	return engine.CurrentRules()
+}
+
+// NewGame rebuilds the match described by the replay in its round 1
+// state, applying o when non-nil.
+func (r *Replay) NewGame(o *Overrides) (*game.Game, error) {
+	var overrides []game.Entity
+	var synergies []game.Synergy
+	if o != nil {
+		overrides = o.Entities
+		synergies = o.Synergies
+	}
+	byID := make(map[uint]game.Entity, len(r.Entities))
+	var byName map[string]game.Entity
+	if overrides != nil {
+		byName = make(map[string]game.Entity, len(overrides))
+		for _, e := range overrides {
+			byName[strings.ToLower(e.Name)] = e
+		}
+	}
+	for _, e := range r.Entities {
+		ge := e.toGame()
+		if byName != nil {
+			o, ok := byName[strings.ToLower(e.Name)]
+			if !ok {
+				return nil, fmt.Errorf("entity %q is not defined in the override config", e.Name)
+			}
+			// Keep the replay's ID so recorded ability choices still resolve.
+			o.ID = e.ID
+			ge = o
+		}
+		byID[e.ID] = ge
+	}
+
+	g := &game.Game{JoinCode: r.JoinCode, RNGSeed: r.Seed, Mode: r.Mode}
+	for _, ps := range r.Players {
+		p := game.Player{PlayerName: ps.Name, Team: ps.Team, HasCreated: true}
+		for _, hs := range ps.Hybrids {
+			ents := make([]game.Entity, 0, len(hs.EntityIDs))
+			for _, id := range hs.EntityIDs {
+				e, ok := byID[id]
+				if !ok {
+					return nil, fmt.Errorf("hybrid %q references unknown entity id %d", hs.Name, id)
+				}
+				ents = append(ents, e)
+			}
+			var h game.Hybrid
+			if overrides != nil {
+				var sel uint
+				if hs.SelectedEntityID != nil {
+					sel = *hs.SelectedEntityID
+				}
+				h = engine.BuildHybridWithSynergies(ents, sel, synergies)
+			} else {
+				h = game.Hybrid{
+					Name:          hs.Name,
+					BaseEntities:  ents,
+					BaseHitPoints: hs.BaseHitPoints,
+					BaseAttack:    hs.BaseAttack,
+					BaseDefense:   hs.BaseDefense,
+					BaseAgility:   hs.BaseAgility,
+					BaseEnergy:    hs.BaseEnergy,
+					BaseVIG:       hs.BaseVIG,
+					Synergies:     hs.Synergies,
+				}
+				if hs.SelectedEntityID != nil {
+					sel := *hs.SelectedEntityID
+					h.SelectedAbilityEntityID = &sel
+				}
+			}
+			h.GeneratedName = hs.GeneratedName
+			p.Hybrids = append(p.Hybrids, h)
+		}
+		g.Players = append(g.Players, p)
+	}
+	engine.PrepareMatchWithRules(g, r.rules(o))
+	return g, nil
+}
+
+// Simulate re-runs every recorded round through the engine and compares
+// the recomputed state with the recorded one, applying o when non-nil.
+func Simulate(r *Replay, o *Overrides) (*Report, error) {
+	g, err := r.NewGame(o)
+	if err != nil {
+		return nil, err
+	}
+	rules := r.rules(o)
+	rep := &Report{}
+	for _, rd := range r.Rounds {
+		if g.Status != game.StatusInProgress {
+			rep.add(rd.Number, "match", string(game.StatusInProgress), describeEnd(g))
+			break
+		}
+		if g.RoundCount != rd.Number {
+			rep.add(rd.Number, "round number", strconv.Itoa(rd.Number), strconv.Itoa(g.RoundCount))
+		}
+		for _, a := range rd.Actions {
+			if a.PlayerIndex < 0 || a.PlayerIndex >= len(g.Players) {
+				return nil, fmt.Errorf("round %d: action for unknown player index %d", rd.Number, a.PlayerIndex)
+			}
+			p := &g.Players[a.PlayerIndex]
+			p.HasSubmittedAction = true
+			p.PendingActionType = a.ActionType
+			p.PendingActionEntityID = nil
+			if a.EntityID != nil {
+				id := *a.EntityID
+				p.PendingActionEntityID = &id
+			}
+			p.PendingSwitchIndex = nil
+			if a.SwitchIndex != nil {
+				idx := *a.SwitchIndex
+				p.PendingSwitchIndex = &idx
+			}
+			p.NextReserveIndex = nil
+			if a.NextReserveIndex != nil {
+				idx := *a.NextReserveIndex
+				p.NextReserveIndex = &idx
+			}
+			p.PendingTargetIndex = nil
+			if a.TargetIndex != nil {
+				idx := *a.TargetIndex
+				p.PendingTargetIndex = &idx
+			}
+		}
+		engine.ResolveRoundWithRules(g, rules)
+		rep.RoundsSimulated++
+		rep.compareSnapshots(rd.Number, rd.Post, g.HybridSnapshots())
+	}
+
+	rep.Status = g.Status
+	rep.Winner = g.Winner
+	rep.Draw = g.Draw
+	last := 0
+	if n := len(r.Rounds); n > 0 {
+		last = r.Rounds[n-1].Number
+	}
+	switch {
+	case g.Status == game.StatusFinished && g.Winner != r.Result.Winner:
+		rep.add(last, "winner", quoteOrNone(r.Result.Winner), quoteOrNone(g.Winner))
+	case g.Status != game.StatusFinished && r.Result.Winner != "":
+		rep.add(last, "winner", quoteOrNone(r.Result.Winner), "match still in progress")
+	case g.Draw != r.Result.Draw:
+		rep.add(last, "draw", strconv.FormatBool(r.Result.Draw), strconv.FormatBool(g.Draw))
+	}
+	return rep, nil
+}
+
+func (rep *Report) add(round int, what, recorded, recomputed string) {
+	rep.Divergences = append(rep.Divergences, Divergence{Round: round, What: what, Recorded: recorded, Recomputed: recomputed})
+}
+
+func (rep *Report) compareSnapshots(round int, recorded, recomputed []game.HybridSnapshot) {
+	type key struct{ p, h int }
+	got := make(map[key]game.HybridSnapshot, len(recomputed))
+	for _, s := range recomputed {
+		got[key{s.PlayerIndex, s.HybridIndex}] = s
+	}
+	for _, want := range recorded {
+		label := fmt.Sprintf("player %d hybrid %d", want.PlayerIndex+1, want.HybridIndex+1)
+		have, ok := got[key{want.PlayerIndex, want.HybridIndex}]
+		if !ok {
+			rep.add(round, label, "present", "missing")
+			continue
+		}
+		cmpInt := func(field string, a, b int) {
+			if a != b {
+				rep.add(round, label+" "+field, strconv.Itoa(a), strconv.Itoa(b))
+			}
+		}
+		cmpBool := func(field string, a, b bool) {
+			if a != b {
+				rep.add(round, label+" "+field, strconv.FormatBool(a), strconv.FormatBool(b))
+			}
+		}
+		cmpInt("current_pv", want.HitPoints, have.HitPoints)
+		cmpInt("current_atq", want.Attack, have.Attack)
+		cmpInt("current_def", want.Defense, have.Defense)
+		cmpInt("current_agi", want.Agility, have.Agility)
+		cmpInt("current_ene", want.Energy, have.Energy)
+		cmpInt("current_vig", want.Vigor, have.Vigor)
+		cmpBool("is_active", want.IsActive, have.IsActive)
+		cmpBool("is_defeated", want.IsDefeated, have.IsDefeated)
+	}
+}
+
+func describeEnd(g *game.Game) string {
+	return string(g.Status) + " (winner " + quoteOrNone(g.Winner) + ")"
+}
+
+func quoteOrNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return strconv.Quote(s)
+}
@@ -2,9 +2,9 @@ package service
 
 import (
 	"errors"
-	"sort"
-	"strings"
 
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 )
 
@@ -15,6 +15,7 @@ type GameRepo interface {
 	GetEntitiesByIDs(ids []uint) ([]game.Entity, error)
 	UpdateGame(g *game.Game) error
 	UpdateStatsOnGameEnd(g *game.Game, resignedEmail string) error
+	RoundRecorder
 }
 
 type CreateHybridSpec struct {
@@ -24,34 +25,26 @@ type CreateHybridSpec struct {
 
 type CreateHybridsRequest struct {
 	PlayerEmail string
-	Hybrid1     CreateHybridSpec
-	Hybrid2     CreateHybridSpec
+	// Hybrids lists one spec per hybrid; the game's HybridsPerPlayer
+	// hybrids are required.
+	Hybrids []CreateHybridSpec
 }
 
 var (
 	ErrGameNotFound           = errors.New("game not found")
 	ErrPlayerNotFound         = errors.New("player not part of game")
 	ErrHybridsAlreadyCreated  = errors.New("hybrids already created")
+	ErrWrongTeamSize          = errors.New("wrong number of hybrids for this game")
 	ErrInvalidHybridCount     = errors.New("each hybrid must have 2 or 3 entities")
 	ErrInvalidSelectedAbility = errors.New("selected ability must reference one of the hybrid's entities")
 	ErrEntityReused           = errors.New("the same entity cannot be reused across hybrids")
 	ErrInvalidEntities        = errors.New("invalid entities for hybrid")
 )
 
-func sumEntityStats(entities []game.Entity) (hitPoints, attack, defense, agility, energy int) {
-	for _, a := range entities {
-		hitPoints += a.HitPoints
-		attack += a.Attack
-		defense += a.Defense
-		agility += a.Agility
-		energy += a.Energy
-	}
-	return
-}
-
-// CreateHybrids builds and stores two hybrids for a player inside a game.
-// It performs all validation and persists the updated game via the repo.
-func CreateHybrids(repo GameRepo, gameID uint, req CreateHybridsRequest) error {
+// CreateHybrids builds and stores a player's team of hybrids inside a
+// game. It performs all validation and persists the updated game via the
+// repo.
+func CreateHybrids(repo GameRepo, bus *events.Bus, gameID uint, req CreateHybridsRequest) error {
 	g, err := repo.GetGameByID(gameID)
 	if err != nil || g == nil {
 		return ErrGameNotFound
@@ -59,9 +52,11 @@ func CreateHybrids(repo GameRepo, gameID uint, req CreateHybridsRequest) error {
 
 	// Find player
 	var p *game.Player
+	idx := -1
 	for i := range g.Players {
 		if (g.Players[i].PlayerEmail) == req.PlayerEmail {
 			p = &g.Players[i]
+			idx = i
 			break
 		}
 	}
@@ -71,12 +66,11 @@ func CreateHybrids(repo GameRepo, gameID uint, req CreateHybridsRequest) error {
 	if p.HasCreated || len(p.Hybrids) > 0 {
 		return ErrHybridsAlreadyCreated
 	}
-
-	validCount := func(n int) bool { return n >= 2 && n <= 3 }
-	if !validCount(len(req.Hybrid1.EntityIDs)) || !validCount(len(req.Hybrid2.EntityIDs)) {
-		return ErrInvalidHybridCount
+	if len(req.Hybrids) != g.HybridsPerPlayer() {
+		return ErrWrongTeamSize
 	}
 
+	validCount := func(n int) bool { return n >= 2 && n <= 3 }
 	contains := func(ids []uint, id uint) bool {
 		for _, x := range ids {
 			if x == id {
@@ -85,81 +79,38 @@ func CreateHybrids(repo GameRepo, gameID uint, req CreateHybridsRequest) error {
 		}
 		return false
 	}
-	if !contains(req.Hybrid1.EntityIDs, req.Hybrid1.SelectedEntityID) || !contains(req.Hybrid2.EntityIDs, req.Hybrid2.SelectedEntityID) {
-		return ErrInvalidSelectedAbility
-	}
-
 	used := map[uint]bool{}
-	for _, id := range append(req.Hybrid1.EntityIDs, req.Hybrid2.EntityIDs...) {
-		if used[id] {
-			return ErrEntityReused
+	for _, spec := range req.Hybrids {
+		if !validCount(len(spec.EntityIDs)) {
+			return ErrInvalidHybridCount
 		}
-		used[id] = true
-	}
-
-	entities1, err := repo.GetEntitiesByIDs(req.Hybrid1.EntityIDs)
-	if err != nil || len(entities1) != len(req.Hybrid1.EntityIDs) || !validCount(len(entities1)) {
-		return ErrInvalidEntities
-	}
-	entities2, err := repo.GetEntitiesByIDs(req.Hybrid2.EntityIDs)
-	if err != nil || len(entities2) != len(req.Hybrid2.EntityIDs) || !validCount(len(entities2)) {
-		return ErrInvalidEntities
-	}
-
-	hp1, at1, def1, agility1, energy1 := sumEntityStats(entities1)
-	hp2, at2, def2, agility2, energy2 := sumEntityStats(entities2)
-
-	clamp := func(x, lo, hi int) int {
-		if x < lo {
-			return lo
+		if !contains(spec.EntityIDs, spec.SelectedEntityID) {
+			return ErrInvalidSelectedAbility
 		}
-		if x > hi {
-			return hi
+		for _, id := range spec.EntityIDs {
+			if used[id] {
+				return ErrEntityReused
+			}
+			used[id] = true
 		}
-		return x
 	}
-	energy1 = clamp(energy1, 1, 3)
-	energy2 = clamp(energy2, 1, 3)
 
-	computeName := func(an []game.Entity) string {
-		names := make([]string, len(an))
-		for i := range an {
-			names[i] = an[i].Name
+	hybrids := make([]game.Hybrid, 0, len(req.Hybrids))
+	for _, spec := range req.Hybrids {
+		entities, err := repo.GetEntitiesByIDs(spec.EntityIDs)
+		if err != nil || len(entities) != len(spec.EntityIDs) || !validCount(len(entities)) {
+			return ErrInvalidEntities
 		}
-		sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
-		return strings.Join(names, " + ")
-	}
-
-	h1 := game.Hybrid{
-		Name:          computeName(entities1),
-		BaseEntities:  entities1,
-		BaseHitPoints: hp1,
-		BaseAttack:    at1,
-		BaseDefense:   def1,
-		BaseAgility:   agility1,
-		BaseEnergy:    energy1,
-	}
-	sel1 := req.Hybrid1.SelectedEntityID
-	h1.SelectedAbilityEntityID = &sel1
-
-	h2 := game.Hybrid{
-		Name:          computeName(entities2),
-		BaseEntities:  entities2,
-		BaseHitPoints: hp2,
-		BaseAttack:    at2,
-		BaseDefense:   def2,
-		BaseAgility:   agility2,
-		BaseEnergy:    energy2,
+		hybrids = append(hybrids, engine.BuildHybrid(entities, spec.SelectedEntityID))
 	}
-	sel2 := req.Hybrid2.SelectedEntityID
-	h2.SelectedAbilityEntityID = &sel2
 
-	p.Hybrids = []game.Hybrid{h1, h2}
+	p.Hybrids = hybrids
 	p.HasCreated = true
 	g.Message = "Hybrids created for a player."
 
 	if err := repo.UpdateGame(g); err != nil {
 		return err
 	}
+	bus.Publish(events.NewHybridsCreated(g.Clone(), idx))
 	return nil
 }
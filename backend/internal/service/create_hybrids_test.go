@@ -37,6 +37,11 @@ func (m *mockRepo) UpdateGame(g *game.Game) error {
 	return nil
 }
 
+func (m *mockRepo) UpdateGameWithRound(g *game.Game, r *game.GameRound) error {
+	m.updatedGame = g
+	return nil
+}
+
 func (m *mockRepo) UpdateStatsOnGameEnd(g *game.Game, resignedEmail string) error {
 	// noop for tests
 	return nil
@@ -56,11 +61,13 @@ func TestCreateHybridsSuccess(t *testing.T) {
 
 	req := CreateHybridsRequest{
 		PlayerEmail: "p1@example.com",
-		Hybrid1:     CreateHybridSpec{EntityIDs: []uint{1, 2}, SelectedEntityID: 1},
-		Hybrid2:     CreateHybridSpec{EntityIDs: []uint{3, 4}, SelectedEntityID: 3},
+		Hybrids: []CreateHybridSpec{
+			{EntityIDs: []uint{1, 2}, SelectedEntityID: 1},
+			{EntityIDs: []uint{3, 4}, SelectedEntityID: 3},
+		},
 	}
 
-	if err := CreateHybrids(mr, 42, req); err != nil {
+	if err := CreateHybrids(mr, nil, 42, req); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -97,11 +104,13 @@ func TestCreateHybrids_ReusedAnimal(t *testing.T) {
 
 	req := CreateHybridsRequest{
 		PlayerEmail: "p1@example.com",
-		Hybrid1:     CreateHybridSpec{EntityIDs: []uint{1, 2}, SelectedEntityID: 1},
-		Hybrid2:     CreateHybridSpec{EntityIDs: []uint{2, 3}, SelectedEntityID: 3},
+		Hybrids: []CreateHybridSpec{
+			{EntityIDs: []uint{1, 2}, SelectedEntityID: 1},
+			{EntityIDs: []uint{2, 3}, SelectedEntityID: 3},
+		},
 	}
 
-	err := CreateHybrids(mr, 100, req)
+	err := CreateHybrids(mr, nil, 100, req)
 	if err == nil {
 		t.Fatalf("expected error for reused entity, got nil")
 	}
@@ -109,3 +118,28 @@ func TestCreateHybrids_ReusedAnimal(t *testing.T) {
 		t.Fatalf("expected ErrEntityReused, got %v", err)
 	}
 }
+
+func TestCreateHybrids_HonorsTeamSize(t *testing.T) {
+	entities := map[uint]game.Entity{}
+	for id, name := range map[uint]string{1: "Lion", 2: "Raven", 3: "Wolf", 4: "Octopus", 5: "Bear", 6: "Turtle"} {
+		entities[id] = game.Entity{Name: name, HitPoints: 4, Attack: 4, Defense: 4, Agility: 4}
+	}
+	specs := []CreateHybridSpec{
+		{EntityIDs: []uint{1, 2}, SelectedEntityID: 1},
+		{EntityIDs: []uint{3, 4}, SelectedEntityID: 3},
+		{EntityIDs: []uint{5, 6}, SelectedEntityID: 6},
+	}
+	g := &game.Game{HybridCount: 3, Players: []game.Player{{PlayerEmail: "p1@example.com"}}}
+	mr := &mockRepo{games: map[uint]*game.Game{7: g}, entities: entities}
+
+	err := CreateHybrids(mr, nil, 7, CreateHybridsRequest{PlayerEmail: "p1@example.com", Hybrids: specs[:2]})
+	if err != ErrWrongTeamSize {
+		t.Fatalf("expected ErrWrongTeamSize for 2 of 3 hybrids, got %v", err)
+	}
+	if err := CreateHybrids(mr, nil, 7, CreateHybridsRequest{PlayerEmail: "p1@example.com", Hybrids: specs}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(g.Players[0].Hybrids); n != 3 {
+		t.Fatalf("expected 3 hybrids, got %d", n)
+	}
+}
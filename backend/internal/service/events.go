@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/logging"
+)
+
+// The services publish their domain events on the bus they are given, once
+// the change is saved and with a copy of the game (g.Clone()) so async
+// subscribers do not see later changes. A nil bus publishes nothing. The
+// events only drive side effects: the state a service must keep
+// consistent, such as the players' stats, is updated by the service itself.
+
+// SubscribeLog logs every domain event.
+func SubscribeLog(b *events.Bus) {
+	events.SubscribeAll(b, "log", events.Async, func(e events.Event) {
+		logging.Info("game event", logging.Fields{"event": e.Name(), constants.LogFieldGameID: e.GameID()})
+	})
+}
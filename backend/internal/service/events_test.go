@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// recordEvents returns a bus and the names of the events published on it.
+func recordEvents() (*events.Bus, *[]string) {
+	b := events.NewBus()
+	var names []string
+	events.SubscribeAll(b, "test", events.Sync, func(e events.Event) { names = append(names, e.Name()) })
+	return b, &names
+}
+
+// decidedInOneRound returns a match that P1 wins by attacking in the first
+// round.
+func decidedInOneRound() *game.Game {
+	return &game.Game{Status: game.StatusInProgress, Phase: game.PhasePlanning, RoundCount: 1, Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 10, CurrentAttack: 10, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 5, CurrentAgility: 5, IsActive: true}}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 2, BaseAttack: 1, CurrentAttack: 1, BaseDefense: 1, CurrentDefense: 1, BaseAgility: 1, CurrentAgility: 1, IsActive: true}}},
+	}}
+}
+
+func TestSubmitAction_PublishesFinishAndCountsStats(t *testing.T) {
+	g := decidedInOneRound()
+	mr := &mockRepoSA{games: map[uint]*game.Game{7: g}}
+	b, names := recordEvents()
+
+	_, _, _ = SubmitAction(mr, b, 7, "p1@example.com", game.PendingActionBasicAttack, 0, time.Minute)
+	if got := *names; len(got) != 1 || got[0] != "action_submitted" || mr.statsCalled {
+		t.Fatalf("expected only action_submitted before the round resolves, got %v", got)
+	}
+	g2, _, err := SubmitAction(mr, b, 7, "p2@example.com", game.PendingActionBasicAttack, 0, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g2.Status != game.StatusFinished || !g2.StatsCounted {
+		t.Fatalf("expected a finished game with its stats counted, got %s", g2.Status)
+	}
+	if got := *names; len(got) != 3 || got[1] != "round_resolved" || got[2] != "game_finished" {
+		t.Fatalf("expected round_resolved then game_finished, got %v", got)
+	}
+	if !mr.statsCalled {
+		t.Fatalf("expected the match to count for the stats")
+	}
+}
+
+func TestHandleTimedOutGame_InactivityDoesNotCountStats(t *testing.T) {
+	g := &game.Game{Status: game.StatusInProgress, Phase: game.PhasePlanning, ActionDeadline: time.Now().Add(-time.Minute), Players: []game.Player{{PlayerName: "A"}, {PlayerName: "B"}}}
+	mr := &mockRepoSA{games: map[uint]*game.Game{1: g}}
+	b, names := recordEvents()
+	if err := HandleTimedOutGame(mr, b, g, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := *names; len(got) != 1 || got[0] != "game_finished" || mr.statsCalled {
+		t.Fatalf("expected game_finished without stats, got %v (stats: %v)", got, mr.statsCalled)
+	}
+}
+
+func TestSubmitAction_CountsStatsWithoutBus(t *testing.T) {
+	g := decidedInOneRound()
+	mr := &mockRepoSA{games: map[uint]*game.Game{7: g}}
+	// Stats do not depend on anything subscribing to the match's events.
+	_, _, _ = SubmitAction(mr, nil, 7, "p1@example.com", game.PendingActionBasicAttack, 0, time.Minute)
+	if _, _, err := SubmitAction(mr, nil, 7, "p2@example.com", game.PendingActionBasicAttack, 0, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mr.statsCalled {
+		t.Fatalf("expected the match to count for the stats without a bus")
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+var ErrInvalidReserve = errors.New("not a valid reserve hybrid")
+
+// SetNextReserve records the reserve (index in the player's hybrids) that
+// enters the arena when the player's active hybrid falls. A nil index
+// restores the default: the first reserve available. The choice can be
+// changed at any time during the planning phase, even after submitting
+// the round's action.
+func SetNextReserve(repo GameRepo, gameID uint, playerEmail string, hybridIndex *int) (*game.Game, error) {
+	g, err := repo.GetGameByID(gameID)
+	if err != nil || g == nil {
+		return nil, ErrGameNotFound
+	}
+	if g.Status != game.StatusInProgress {
+		return nil, ErrGameNotInProgress
+	}
+	if g.Phase != game.PhasePlanning {
+		return nil, ErrActionsLocked
+	}
+	var p *game.Player
+	for i := range g.Players {
+		if g.Players[i].PlayerEmail == playerEmail {
+			p = &g.Players[i]
+			break
+		}
+	}
+	if p == nil {
+		return nil, ErrPlayerNotInGame
+	}
+	if hybridIndex != nil {
+		if engine.SwitchTarget(p, hybridIndex) == nil {
+			return nil, ErrInvalidReserve
+		}
+		idx := *hybridIndex
+		hybridIndex = &idx
+	}
+	p.NextReserveIndex = hybridIndex
+	if err := repo.UpdateGame(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// RoundRecorder is implemented by repositories that can persist a game
+// together with the history record of the round that was just resolved,
+// atomically.
+type RoundRecorder interface {
+	UpdateGameWithRound(g *game.Game, r *game.GameRound) error
+}
+
+// resolveRound runs engine.ResolveRound on g and returns the history
+// record describing it. autoRest lists the players whose action was
+// auto-submitted after a timeout.
+func resolveRound(g *game.Game, autoRest ...int) *game.GameRound {
+	r := &game.GameRound{
+		GameID:      g.ID,
+		RoundNumber: g.RoundCount,
+		Actions:     g.PendingRoundActions(autoRest...),
+		Pre:         g.HybridSnapshots(),
+	}
+	engine.ResolveRound(g)
+	r.Post = g.HybridSnapshots()
+	r.Summary = g.LastRoundSummary
+	r.Events = g.LastRoundEvents
+	r.ResolvedAt = time.Now()
+	return r
+}
+
+// saveResolved persists g and, when the repository supports it, the round
+// history record in the same transaction. When r is set, RoundResolved is
+// published on bus once saved.
+func saveResolved(repo interface{ UpdateGame(*game.Game) error }, bus *events.Bus, g *game.Game, r *game.GameRound) error {
+	var err error
+	if rr, ok := repo.(RoundRecorder); ok && r != nil {
+		err = rr.UpdateGameWithRound(g, r)
+	} else {
+		err = repo.UpdateGame(g)
+	}
+	if err == nil && r != nil {
+		bus.Publish(events.NewRoundResolved(g.Clone(), r))
+	}
+	return err
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/ericogr/chimera-cards/internal/constants"
+	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/hybridimage"
 	"github.com/ericogr/chimera-cards/internal/hybridname"
@@ -12,17 +15,18 @@ import (
 )
 
 var (
-	ErrPlayersNotReady      = errors.New("both players must create hybrids before starting")
-	ErrEachPlayerTwoHybrids = errors.New("each player must have two hybrids")
+	ErrPlayersNotReady = errors.New("every player must create hybrids before starting")
+	ErrTeamIncomplete  = errors.New("each player must have the game's number of hybrids")
 )
 
 // StartGame performs all server-side initialization when starting a game.
 // It generates AI names for hybrids (or loads them from cache), initializes
-// combat stats, and updates the game state. The provided game object is
-// modified and persisted using the repository.
-func StartGame(repo storage.Repository, g *game.Game) error {
-	// Ensure both players created hybrids
-	if len(g.Players) != 2 || !g.Players[0].HasCreated || !g.Players[1].HasCreated {
+// combat stats, opens the first planning phase with its action deadline,
+// and updates the game state. The provided game object is modified and
+// persisted using the repository.
+func StartGame(repo storage.Repository, bus *events.Bus, g *game.Game, actionTimeout time.Duration) error {
+	// Ensure every seat is taken and every player created hybrids
+	if len(g.Players) != g.MaxPlayers() || !g.AllCreated() {
 		return ErrPlayersNotReady
 	}
 
@@ -31,59 +35,32 @@ func StartGame(repo storage.Repository, g *game.Game) error {
 		return err
 	}
 
-	// Initialize current stats and set first hybrid active
 	for i := range g.Players {
-		if len(g.Players[i].Hybrids) < 2 {
-			return ErrEachPlayerTwoHybrids
-		}
-		for j := range g.Players[i].Hybrids {
-			hbd := &g.Players[i].Hybrids[j]
-			hbd.CurrentHitPoints = hbd.BaseHitPoints
-			hbd.CurrentAttack = hbd.BaseAttack
-			hbd.CurrentDefense = hbd.BaseDefense
-			hbd.CurrentAgility = hbd.BaseAgility
-			hbd.CurrentEnergy = hbd.BaseEnergy
-			// Initialize VIG: base and current (fast, fair, simple: base=3)
-			if hbd.BaseVIG == 0 {
-				hbd.BaseVIG = 3
-			}
-			hbd.CurrentVIG = hbd.BaseVIG
-			hbd.IsDefeated = false
-			hbd.IsActive = (j == 0)
+		if len(g.Players[i].Hybrids) != g.HybridsPerPlayer() {
+			return ErrTeamIncomplete
 		}
 	}
 
-	// Prepare game state
-	g.Status = game.StatusInProgress
-	g.RoundCount = 1
-	g.TurnNumber = 1
-	g.Phase = game.PhasePlanning
-	g.Message = "The game has started. Choose your actions."
-
-	// Round start adjustments
-	for i := range g.Players {
-		g.Players[i].HasSubmittedAction = false
-		g.Players[i].PendingActionType = game.PendingActionNone
-		g.Players[i].PendingActionEntityID = nil
-		for j := range g.Players[i].Hybrids {
-			if g.Players[i].Hybrids[j].IsActive && !g.Players[i].Hybrids[j].IsDefeated {
-				g.Players[i].Hybrids[j].CurrentEnergy += 1
-				g.Players[i].Hybrids[j].LastAction = ""
-				g.Players[i].Hybrids[j].DefendStanceActive = false
-				g.Players[i].Hybrids[j].DefenseBuffMultiplier = 0
-				g.Players[i].Hybrids[j].DefenseBuffUntilRound = 0
-				g.Players[i].Hybrids[j].AttackBuffPercent = 0
-				g.Players[i].Hybrids[j].AttackBuffUntilRound = 0
-				g.Players[i].Hybrids[j].AttackDebuffPercent = 0
-				g.Players[i].Hybrids[j].AttackDebuffUntilRound = 0
-			}
-		}
+	// Initialize combat state for round 1
+	if g.RNGSeed == 0 {
+		g.RNGSeed = engine.NewSeed()
+	}
+	// Record the ruleset so the match (and its replay) keeps the rules it
+	// started with whatever the configuration says later.
+	if g.Rules == nil {
+		rules := engine.CurrentRules()
+		g.Rules = &rules
 	}
+	engine.PrepareMatch(g)
+	// Set in the same save as the rest of the start so nothing reacting to
+	// GameStarted (such as the bots) races with it.
+	g.ActionDeadline = time.Now().Add(actionTimeout)
 
 	// Persist the updated game
 	if err := repo.UpdateGame(g); err != nil {
 		return err
 	}
+	bus.Publish(events.NewGameStarted(g.Clone()))
 	return nil
 }
 
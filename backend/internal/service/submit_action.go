@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 )
 
@@ -14,12 +15,58 @@ var (
 	ErrPlayerNotInGame            = errors.New("player not in game")
 	ErrNoActiveHybrid             = errors.New("no active hybrid")
 	ErrHybridHasNoSelectedAbility = errors.New("hybrid has no selected ability")
-	ErrAbilityMismatch            = errors.New("ability must match the hybrid's selected entity")
+	ErrAbilityMismatch            = errors.New("ability is not usable by the active hybrid")
+	ErrHybridStunned              = errors.New("hybrid is stunned and skips this round")
+	ErrInvalidSwitch              = errors.New("no valid reserve hybrid to switch to")
+	ErrNotEnoughEnergy            = errors.New("not enough energy for the ability")
+	ErrAbilityOnCooldown          = errors.New("ability is on cooldown")
+	ErrInvalidTarget              = errors.New("target is not an enemy in play")
 )
 
-// SubmitAction stores a player's chosen action and resolves the round if both players submitted.
+// SubmitAction stores a player's chosen action and resolves the round if every player submitted.
 // Returns the updated game and a boolean indicating whether the round was resolved.
-func SubmitAction(repo GameRepo, gameID uint, playerEmail string, actionType game.PendingActionType, entityID uint, actionTimeout time.Duration) (*game.Game, bool, error) {
+func SubmitAction(repo GameRepo, bus *events.Bus, gameID uint, playerEmail string, actionType game.PendingActionType, entityID uint, actionTimeout time.Duration) (*game.Game, bool, error) {
+	return submitAction(repo, bus, gameID, []string{playerEmail}, actionInput{actionType: actionType, entityID: entityID}, actionTimeout, false)
+}
+
+// SubmitTargetedAction is SubmitAction aimed at the enemy player at
+// targetIndex (nil targets the first enemy in play). The target only
+// matters for attacks and abilities.
+func SubmitTargetedAction(repo GameRepo, bus *events.Bus, gameID uint, playerEmail string, actionType game.PendingActionType, entityID uint, targetIndex *int, actionTimeout time.Duration) (*game.Game, bool, error) {
+	return submitAction(repo, bus, gameID, []string{playerEmail}, actionInput{actionType: actionType, entityID: entityID, targetIndex: targetIndex}, actionTimeout, false)
+}
+
+// SubmitSwitch submits a `switch` action that brings the reserve at
+// hybridIndex into the arena (nil picks the first available reserve).
+func SubmitSwitch(repo GameRepo, bus *events.Bus, gameID uint, playerEmail string, hybridIndex *int, actionTimeout time.Duration) (*game.Game, bool, error) {
+	return submitAction(repo, bus, gameID, []string{playerEmail}, actionInput{actionType: game.PendingActionSwitch, switchIndex: hybridIndex}, actionTimeout, false)
+}
+
+// SubmitAutoRest submits `rest` on behalf of a player who missed the
+// action deadline. It behaves like SubmitAction but flags the action as
+// automatic in the round history.
+func SubmitAutoRest(repo GameRepo, bus *events.Bus, gameID uint, playerEmail string, actionTimeout time.Duration) (*game.Game, bool, error) {
+	return SubmitAutoRests(repo, bus, gameID, []string{playerEmail}, actionTimeout)
+}
+
+// SubmitAutoRests is SubmitAutoRest for several players at once, so a team
+// match where more than one player missed the deadline resolves with every
+// automatic action flagged.
+func SubmitAutoRests(repo GameRepo, bus *events.Bus, gameID uint, playerEmails []string, actionTimeout time.Duration) (*game.Game, bool, error) {
+	return submitAction(repo, bus, gameID, playerEmails, actionInput{actionType: game.PendingActionRest}, actionTimeout, true)
+}
+
+// actionInput is the action a player submits for the current round.
+type actionInput struct {
+	actionType  game.PendingActionType
+	entityID    uint
+	switchIndex *int
+	targetIndex *int
+}
+
+// submitAction stores in as the action of every player in playerEmails and
+// resolves the round once all players submitted.
+func submitAction(repo GameRepo, bus *events.Bus, gameID uint, playerEmails []string, in actionInput, actionTimeout time.Duration, auto bool) (*game.Game, bool, error) {
 	g, err := repo.GetGameByID(gameID)
 	if err != nil || g == nil {
 		return nil, false, ErrGameNotFound
@@ -30,48 +77,26 @@ func SubmitAction(repo GameRepo, gameID uint, playerEmail string, actionType gam
 	if g.Phase != game.PhasePlanning {
 		return nil, false, ErrActionsLocked
 	}
-	if len(g.Players) != 2 {
+	if len(g.Players) != g.MaxPlayers() {
 		return nil, false, errors.New("invalid player count")
 	}
 
-	var current *game.Player
-	if (g.Players[0].PlayerEmail) == playerEmail {
-		current = &g.Players[0]
-	} else if (g.Players[1].PlayerEmail) == playerEmail {
-		current = &g.Players[1]
-	} else {
-		return nil, false, ErrPlayerNotInGame
-	}
-
-	var active *game.Hybrid
-	for i := range current.Hybrids {
-		if current.Hybrids[i].IsActive && !current.Hybrids[i].IsDefeated {
-			active = &current.Hybrids[i]
-			break
-		}
-	}
-	if active == nil {
-		return nil, false, ErrNoActiveHybrid
-	}
-
-	current.HasSubmittedAction = true
-	current.PendingActionType = actionType
-	if actionType == game.PendingActionAbility {
-		if active.SelectedAbilityEntityID == nil {
-			return nil, false, ErrHybridHasNoSelectedAbility
+	var submitted, autoIdx []int
+	for _, email := range playerEmails {
+		idx, err := storeAction(g, email, in)
+		if err != nil {
+			return nil, false, err
 		}
-		if entityID != 0 && entityID != *active.SelectedAbilityEntityID {
-			return nil, false, ErrAbilityMismatch
+		submitted = append(submitted, idx)
+		if auto {
+			autoIdx = append(autoIdx, idx)
 		}
-		aid := *active.SelectedAbilityEntityID
-		current.PendingActionEntityID = &aid
-	} else {
-		current.PendingActionEntityID = nil
 	}
 
-	resolved := false
-	if g.Players[0].HasSubmittedAction && g.Players[1].HasSubmittedAction {
-		engine.ResolveRound(g)
+	resolved, finished := false, false
+	var round *game.GameRound
+	if g.AllSubmitted() {
+		round = resolveRound(g, autoIdx...)
 		// If the match continues, reset the action deadline for the next round;
 		// otherwise mark stats as counted so no further updates occur.
 		if g.Status == game.StatusFinished {
@@ -79,6 +104,7 @@ func SubmitAction(repo GameRepo, gameID uint, playerEmail string, actionType gam
 				// keep existing behavior for normal finishes
 				_ = repo.UpdateStatsOnGameEnd(g, "")
 				g.StatsCounted = true
+				finished = true
 			}
 		} else {
 			// New planning phase started; reset deadline
@@ -87,9 +113,103 @@ func SubmitAction(repo GameRepo, gameID uint, playerEmail string, actionType gam
 		resolved = true
 	}
 
-	if err := repo.UpdateGame(g); err != nil {
+	if err := saveResolved(repo, bus, g, round); err != nil {
 		return nil, resolved, err
 	}
+	if finished {
+		bus.Publish(events.NewGameFinished(g.Clone(), events.FinishDecided, ""))
+	}
+	if !resolved {
+		for _, idx := range submitted {
+			bus.Publish(events.NewActionSubmitted(g.Clone(), idx))
+		}
+	}
 
 	return g, resolved, nil
 }
+
+// storeAction validates in for the player with playerEmail and records it
+// as their pending action. It returns the player's index.
+func storeAction(g *game.Game, playerEmail string, in actionInput) (int, error) {
+	actionType, entityID := in.actionType, in.entityID
+	var current *game.Player
+	currentIdx := -1
+	for i := range g.Players {
+		if g.Players[i].PlayerEmail == playerEmail {
+			current = &g.Players[i]
+			currentIdx = i
+			break
+		}
+	}
+	if current == nil {
+		return -1, ErrPlayerNotInGame
+	}
+
+	var active *game.Hybrid
+	for i := range current.Hybrids {
+		if current.Hybrids[i].IsActive && !current.Hybrids[i].IsDefeated {
+			active = &current.Hybrids[i]
+			break
+		}
+	}
+	if active == nil {
+		return -1, ErrNoActiveHybrid
+	}
+
+	// A stunned hybrid's skip is submitted by the engine at round start.
+	if engine.IsStunned(active, g.RoundCount) && current.HasSubmittedAction {
+		return -1, ErrHybridStunned
+	}
+
+	current.PendingSwitchIndex = nil
+	if actionType == game.PendingActionSwitch {
+		target := engine.SwitchTarget(current, in.switchIndex)
+		if target == nil {
+			return -1, ErrInvalidSwitch
+		}
+		for i := range current.Hybrids {
+			if &current.Hybrids[i] == target {
+				idx := i
+				current.PendingSwitchIndex = &idx
+				break
+			}
+		}
+	}
+
+	var abilityID *uint
+	if actionType == game.PendingActionAbility {
+		// entity_id 0 selects the primary ability.
+		if entityID == 0 {
+			if active.SelectedAbilityEntityID == nil {
+				return -1, ErrHybridHasNoSelectedAbility
+			}
+			entityID = *active.SelectedAbilityEntityID
+		}
+		rules := engine.RulesFor(g)
+		ch := engine.UsableAbility(active, entityID, rules)
+		if ch == nil {
+			return -1, ErrAbilityMismatch
+		}
+		switch engine.AbilityBlocked(active, ch, rules) {
+		case engine.AbilityOnCooldown:
+			return -1, ErrAbilityOnCooldown
+		case engine.AbilityNotEnoughEnergy:
+			return -1, ErrNotEnoughEnergy
+		}
+		aid := ch.ID
+		abilityID = &aid
+	}
+	var target *int
+	if in.targetIndex != nil && (actionType == game.PendingActionBasicAttack || actionType == game.PendingActionAbility) {
+		if engine.ActionTarget(g, current, in.targetIndex) == nil {
+			return -1, ErrInvalidTarget
+		}
+		idx := *in.targetIndex
+		target = &idx
+	}
+	current.PendingTargetIndex = target
+	current.HasSubmittedAction = true
+	current.PendingActionType = actionType
+	current.PendingActionEntityID = abilityID
+	return currentIdx, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ericogr/chimera-cards/internal/engine"
 	"github.com/ericogr/chimera-cards/internal/game"
 )
 
@@ -12,6 +13,7 @@ type mockRepoSA struct {
 	games       map[uint]*game.Game
 	updatedGame *game.Game
 	statsCalled bool
+	rounds      []*game.GameRound
 }
 
 func (m *mockRepoSA) GetGameByID(id uint) (*game.Game, error) {
@@ -30,6 +32,12 @@ func (m *mockRepoSA) UpdateGame(g *game.Game) error {
 	return nil
 }
 
+func (m *mockRepoSA) UpdateGameWithRound(g *game.Game, r *game.GameRound) error {
+	m.updatedGame = g
+	m.rounds = append(m.rounds, r)
+	return nil
+}
+
 func (m *mockRepoSA) UpdateStatsOnGameEnd(g *game.Game, resignedEmail string) error {
 	m.statsCalled = true
 	return nil
@@ -47,7 +55,7 @@ func TestSubmitAction_ResolvesRound(t *testing.T) {
 	mr := &mockRepoSA{games: map[uint]*game.Game{7: g}}
 
 	// First player submits
-	_, resolved, err := SubmitAction(mr, 7, "p1@example.com", game.PendingActionBasicAttack, 0, 1*time.Minute)
+	_, resolved, err := SubmitAction(mr, nil, 7, "p1@example.com", game.PendingActionBasicAttack, 0, 1*time.Minute)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -56,7 +64,7 @@ func TestSubmitAction_ResolvesRound(t *testing.T) {
 	}
 
 	// Second player submits -> should resolve
-	g2, resolved, err := SubmitAction(mr, 7, "p2@example.com", game.PendingActionBasicAttack, 0, 1*time.Minute)
+	g2, resolved, err := SubmitAction(mr, nil, 7, "p2@example.com", game.PendingActionBasicAttack, 0, 1*time.Minute)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -67,3 +75,264 @@ func TestSubmitAction_ResolvesRound(t *testing.T) {
 		t.Fatalf("expected RoundCount=2 after resolution, got %d", g2.RoundCount)
 	}
 }
+
+func TestSubmitAutoRest_RecordsRoundHistory(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 5, CurrentAttack: 5, BaseAgility: 5, CurrentAgility: 5, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 1, CurrentAttack: 1, BaseAgility: 1, CurrentAgility: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	mr := &mockRepoSA{games: map[uint]*game.Game{3: g}}
+
+	if _, _, err := SubmitAction(mr, nil, 3, "p1@example.com", game.PendingActionBasicAttack, 0, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, resolved, err := SubmitAutoRest(mr, nil, 3, "p2@example.com", time.Minute); err != nil || !resolved {
+		t.Fatalf("expected auto-rest to resolve the round, resolved=%v err=%v", resolved, err)
+	}
+	if len(mr.rounds) != 1 {
+		t.Fatalf("expected one round record, got %d", len(mr.rounds))
+	}
+	r := mr.rounds[0]
+	if r.RoundNumber != 1 || len(r.Actions) != 2 {
+		t.Fatalf("unexpected round record: %+v", r)
+	}
+	if r.Actions[0].ActionType != game.PendingActionBasicAttack || r.Actions[0].AutoRest {
+		t.Fatalf("unexpected p1 action: %+v", r.Actions[0])
+	}
+	if r.Actions[1].ActionType != game.PendingActionRest || !r.Actions[1].AutoRest {
+		t.Fatalf("expected p2 auto-rest, got %+v", r.Actions[1])
+	}
+	if r.Pre[1].HitPoints != 10 || r.Post[1].HitPoints >= 10 {
+		t.Fatalf("expected snapshots to capture damage, pre=%+v post=%+v", r.Pre[1], r.Post[1])
+	}
+	if r.Summary == "" || len(r.Events) == 0 {
+		t.Fatalf("expected summary and events to be recorded")
+	}
+}
+
+func TestSubmitSwitch_ValidatesTarget(t *testing.T) {
+	g := &game.Game{Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{
+			{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true},
+			{Name: "H1b", BaseHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsDefeated: true},
+			{Name: "H1c", BaseHitPoints: 10, BaseVIG: 3, CurrentVIG: 3},
+		}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true}}},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	mr := &mockRepoSA{games: map[uint]*game.Game{5: g}}
+
+	for _, idx := range []int{0, 1, 3} {
+		i := idx
+		if _, _, err := SubmitSwitch(mr, nil, 5, "p1@example.com", &i, time.Minute); err != ErrInvalidSwitch {
+			t.Fatalf("index %d: expected ErrInvalidSwitch, got %v", idx, err)
+		}
+	}
+
+	// Without an index the first available reserve is chosen.
+	if _, _, err := SubmitSwitch(mr, nil, 5, "p1@example.com", nil, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := g.Players[0].PendingSwitchIndex; p == nil || *p != 2 {
+		t.Fatalf("expected pending switch to index 2, got %v", p)
+	}
+	if _, resolved, err := SubmitAction(mr, nil, 5, "p2@example.com", game.PendingActionRest, 0, time.Minute); err != nil || !resolved {
+		t.Fatalf("expected the round to resolve, resolved=%v err=%v", resolved, err)
+	}
+	if !g.Players[0].Hybrids[2].IsActive || g.Players[0].Hybrids[0].IsActive {
+		t.Fatalf("expected H1c to be active after the switch")
+	}
+	if a := mr.rounds[0].Actions[0]; a.ActionType != game.PendingActionSwitch || a.SwitchIndex == nil || *a.SwitchIndex != 2 {
+		t.Fatalf("expected the switch to be recorded, got %+v", a)
+	}
+}
+
+func TestSubmitAction_RejectsUnaffordableAndCoolingAbilities(t *testing.T) {
+	roar := game.Entity{Name: "Lion", Skill: game.Skill{Name: "Roar", Key: "skill:roar", Cost: 2, Cooldown: 1}}
+	roar.ID = 7
+	g := &game.Game{Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{roar}, SelectedAbilityEntityID: &roar.ID, BaseHitPoints: 10, CurrentHitPoints: 10, CurrentEnergy: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	mr := &mockRepoSA{games: map[uint]*game.Game{5: g}}
+	h1 := &g.Players[0].Hybrids[0]
+	restBoth := func(ability bool) {
+		t.Helper()
+		act := game.PendingActionRest
+		if ability {
+			act = game.PendingActionAbility
+		}
+		if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", act, 0, time.Minute); err != nil {
+			t.Fatalf("round %d: unexpected error: %v", g.RoundCount, err)
+		}
+		if _, resolved, err := SubmitAction(mr, nil, 5, "p2@example.com", game.PendingActionRest, 0, time.Minute); err != nil || !resolved {
+			t.Fatalf("round %d: expected the round to resolve, resolved=%v err=%v", g.RoundCount, resolved, err)
+		}
+	}
+
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionAbility, 0, time.Minute); err != ErrNotEnoughEnergy {
+		t.Fatalf("expected ErrNotEnoughEnergy, got %v", err)
+	}
+	if g.Players[0].HasSubmittedAction {
+		t.Fatalf("a rejected action must not be stored")
+	}
+	h1.CurrentEnergy = 5
+	restBoth(true)
+	if cd := h1.AbilityCooldowns[roar.ID]; cd != 1 {
+		t.Fatalf("expected 1 round of cooldown in the payload, got %d", cd)
+	}
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionAbility, 0, time.Minute); err != ErrAbilityOnCooldown {
+		t.Fatalf("expected ErrAbilityOnCooldown, got %v", err)
+	}
+	restBoth(false)
+	if len(h1.AbilityCooldowns) != 0 {
+		t.Fatalf("expected the cooldown to expire, got %v", h1.AbilityCooldowns)
+	}
+	restBoth(true)
+}
+
+func TestSubmitAction_SecondaryAbilities(t *testing.T) {
+	primary := game.Entity{Name: "Lion", Skill: game.Skill{Name: "Roar", Cost: 1}}
+	primary.ID = 1
+	secondary := game.Entity{Name: "Wolf", Skill: game.Skill{Name: "Pack Tactics", Cost: 2, Effect: game.SkillEffect{RestoreEnergy: 4}}}
+	secondary.ID = 2
+	g := &game.Game{Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{{Name: "H1", BaseEntities: []game.Entity{primary, secondary}, SelectedAbilityEntityID: &primary.ID, BaseHitPoints: 10, CurrentHitPoints: 10, CurrentEnergy: 2, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true}}},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	mr := &mockRepoSA{games: map[uint]*game.Game{5: g}}
+
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionAbility, secondary.ID, time.Minute); err != ErrAbilityMismatch {
+		t.Fatalf("expected ErrAbilityMismatch outside all-abilities mode, got %v", err)
+	}
+
+	prev := engine.CurrentRules()
+	t.Cleanup(func() { engine.SetRules(prev) })
+	r := game.DefaultRules()
+	r.Abilities = game.AbilityRules{AllUsable: true, SecondaryEnergySurcharge: 1}
+	engine.SetRules(r)
+
+	// 2 ENE + 1 surcharge: one Energy short.
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionAbility, secondary.ID, time.Minute); err != ErrNotEnoughEnergy {
+		t.Fatalf("expected ErrNotEnoughEnergy with the surcharge, got %v", err)
+	}
+	g.Players[0].Hybrids[0].CurrentEnergy = 3
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionAbility, secondary.ID, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, resolved, err := SubmitAction(mr, nil, 5, "p2@example.com", game.PendingActionRest, 0, time.Minute); err != nil || !resolved {
+		t.Fatalf("expected the round to resolve, resolved=%v err=%v", resolved, err)
+	}
+	// 3 - 3 + 4 restored + 1 per round.
+	if ene := g.Players[0].Hybrids[0].CurrentEnergy; ene != 5 {
+		t.Fatalf("expected 5 ENE after the secondary ability, got %d", ene)
+	}
+	if a := mr.rounds[0].Actions[0]; a.EntityID == nil || *a.EntityID != secondary.ID {
+		t.Fatalf("expected the secondary ability to be recorded, got %+v", a)
+	}
+}
+
+func TestSetNextReserve(t *testing.T) {
+	g := &game.Game{HybridCount: 3, Players: []game.Player{
+		{PlayerEmail: "p1@example.com", PlayerName: "P1", Hybrids: []game.Hybrid{
+			{Name: "H1", BaseHitPoints: 10, CurrentHitPoints: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true},
+			{Name: "H1b", BaseHitPoints: 10, BaseVIG: 3, CurrentVIG: 3},
+			{Name: "H1c", BaseHitPoints: 10, BaseVIG: 3, CurrentVIG: 3},
+		}},
+		{PlayerEmail: "p2@example.com", PlayerName: "P2", Hybrids: []game.Hybrid{{Name: "H2", BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 10, CurrentAttack: 10, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true}}},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	mr := &mockRepoSA{games: map[uint]*game.Game{5: g}}
+
+	for _, idx := range []int{0, 3} {
+		i := idx
+		if _, err := SetNextReserve(mr, 5, "p1@example.com", &i); err != ErrInvalidReserve {
+			t.Fatalf("index %d: expected ErrInvalidReserve, got %v", idx, err)
+		}
+	}
+	if _, err := SetNextReserve(mr, 5, "p3@example.com", nil); err != ErrPlayerNotInGame {
+		t.Fatalf("expected ErrPlayerNotInGame, got %v", err)
+	}
+
+	next := 2
+	if _, err := SetNextReserve(mr, 5, "p1@example.com", &next); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := SubmitAction(mr, nil, 5, "p1@example.com", game.PendingActionRest, 0, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, resolved, err := SubmitAction(mr, nil, 5, "p2@example.com", game.PendingActionBasicAttack, 0, time.Minute); err != nil || !resolved {
+		t.Fatalf("expected the round to resolve, resolved=%v err=%v", resolved, err)
+	}
+	if !g.Players[0].Hybrids[2].IsActive || g.Players[0].Hybrids[1].IsActive {
+		t.Fatalf("expected the chosen reserve H1c to enter the arena")
+	}
+	if a := mr.rounds[0].Actions[0]; a.NextReserveIndex == nil || *a.NextReserveIndex != 2 {
+		t.Fatalf("expected the reserve choice to be recorded, got %+v", a)
+	}
+	if g.Status != game.StatusInProgress {
+		t.Fatalf("expected the match to continue while reserves remain, got %s", g.Status)
+	}
+}
+
+func TestSubmitTargetedAction_TeamGame(t *testing.T) {
+	hybrid := func(name string) []game.Hybrid {
+		return []game.Hybrid{{Name: name, BaseHitPoints: 10, CurrentHitPoints: 10, BaseAttack: 5, CurrentAttack: 5, BaseDefense: 1, CurrentDefense: 1, BaseVIG: 3, CurrentVIG: 3, IsActive: true, Revealed: true}}
+	}
+	g := &game.Game{Mode: game.ModeTeams, Players: []game.Player{
+		{PlayerEmail: "a@example.com", PlayerName: "A", Team: 0, Hybrids: hybrid("HA")},
+		{PlayerEmail: "b@example.com", PlayerName: "B", Team: 1, Hybrids: hybrid("HB")},
+		{PlayerEmail: "c@example.com", PlayerName: "C", Team: 0, Hybrids: hybrid("HC")},
+		{PlayerEmail: "d@example.com", PlayerName: "D", Team: 1, Hybrids: hybrid("HD")},
+	}}
+	g.RoundCount = 1
+	g.Status = game.StatusInProgress
+	g.Phase = game.PhasePlanning
+	g.ID = 9
+	mr := &mockRepoSA{games: map[uint]*game.Game{9: g}}
+
+	mate := 2
+	if _, _, err := SubmitTargetedAction(mr, nil, 9, "a@example.com", game.PendingActionBasicAttack, 0, &mate, time.Minute); err != ErrInvalidTarget {
+		t.Fatalf("expected ErrInvalidTarget for a teammate, got %v", err)
+	}
+	d := 3
+	if _, resolved, err := SubmitTargetedAction(mr, nil, 9, "a@example.com", game.PendingActionBasicAttack, 0, &d, time.Minute); err != nil || resolved {
+		t.Fatalf("expected the action to be stored, resolved=%v err=%v", resolved, err)
+	}
+	for _, email := range []string{"b@example.com", "c@example.com"} {
+		if _, resolved, err := SubmitAction(mr, nil, 9, email, game.PendingActionRest, 0, time.Minute); err != nil || resolved {
+			t.Fatalf("%s: expected the round to wait for every player, resolved=%v err=%v", email, resolved, err)
+		}
+	}
+
+	// The last player misses the deadline and rests automatically.
+	if err := HandleTimedOutGame(mr, nil, g, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mr.rounds) != 1 {
+		t.Fatalf("expected the round to resolve, got %d rounds", len(mr.rounds))
+	}
+	if hp := g.Players[3].Hybrids[0].CurrentHitPoints; hp != 6 {
+		t.Fatalf("expected D to take A's attack, got PV=%d", hp)
+	}
+	acts := mr.rounds[0].Actions
+	if a := acts[0]; a.TargetIndex == nil || *a.TargetIndex != 3 {
+		t.Fatalf("expected A's target to be recorded, got %+v", a)
+	}
+	if !acts[3].AutoRest || acts[1].AutoRest {
+		t.Fatalf("expected only D's rest to be automatic, got %+v", acts)
+	}
+}
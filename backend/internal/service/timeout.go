@@ -3,101 +3,109 @@ package service
 import (
 	"time"
 
-	"github.com/ericogr/chimera-cards/internal/engine"
+	"github.com/ericogr/chimera-cards/internal/events"
 	"github.com/ericogr/chimera-cards/internal/game"
 	"github.com/ericogr/chimera-cards/internal/logging"
 )
 
 // HandleTimedOutGame applies timeout resolution for a single game.
 // Behavior:
-// - both players didn't submit -> finish match with no winner
-// - exactly one player didn't submit -> auto-submit rest for that player
-// The function uses SubmitAction when the repo implements GameRepo; otherwise
-// it falls back to inline resolution via engine.ResolveRound.
+// - no player submitted -> finish match with no winner
+// - some players didn't submit -> auto-submit rest for them
+// The function uses SubmitAutoRests when the repo implements GameRepo;
+// otherwise it falls back to inline resolution via engine.ResolveRound.
 func HandleTimedOutGame(repo interface {
 	GetGameByID(uint) (*game.Game, error)
 	UpdateGame(*game.Game) error
 	UpdateStatsOnGameEnd(*game.Game, string) error
-}, gg *game.Game, actionTimeout time.Duration) error {
+}, bus *events.Bus, gg *game.Game, actionTimeout time.Duration) error {
 	if gg.Status != game.StatusInProgress || gg.Phase != game.PhasePlanning {
 		return nil
 	}
 
-	if len(gg.Players) != 2 {
+	if len(gg.Players) != gg.MaxPlayers() {
 		gg.Status = game.StatusFinished
 		gg.Phase = game.PhaseResolved
 		gg.Winner = ""
 		gg.Message = "Match ended due to inactivity"
 		gg.LastRoundSummary = "no resolution was reached due to inactivity."
+		gg.LastRoundEvents = nil
 		gg.StatsCounted = true
 		gg.ActionDeadline = time.Time{}
-		return repo.UpdateGame(gg)
+		return finishInactive(repo, bus, gg)
 	}
 
-	p1 := &gg.Players[0]
-	p2 := &gg.Players[1]
-	p1Submitted := p1.HasSubmittedAction
-	p2Submitted := p2.HasSubmittedAction
+	var missing []int
+	for i := range gg.Players {
+		if !gg.Players[i].HasSubmittedAction {
+			missing = append(missing, i)
+		}
+	}
 
 	switch {
-	case !p1Submitted && !p2Submitted:
+	case len(missing) == len(gg.Players):
 		gg.Status = game.StatusFinished
 		gg.Phase = game.PhaseResolved
 		gg.Winner = ""
 		gg.Message = "Match ended due to inactivity"
 		gg.LastRoundSummary = "Round timed out: both players failed to submit actions within the allotted time."
+		if len(gg.Players) > 2 {
+			gg.LastRoundSummary = "Round timed out: no player submitted an action within the allotted time."
+		}
+		gg.LastRoundEvents = nil
 		gg.StatsCounted = true
 		gg.ActionDeadline = time.Time{}
-		logging.Info("both players timed out; finishing game", nil)
-		return repo.UpdateGame(gg)
-	case p1Submitted && !p2Submitted:
-		logging.Info("auto-submitting rest for inactive player (p2)", nil)
-		// try to use SubmitAction path if repo implements GameRepo
+		logging.Info("all players timed out; finishing game", nil)
+		return finishInactive(repo, bus, gg)
+	case len(missing) > 0:
+		logging.Info("auto-submitting rest for inactive players", logging.Fields{"players": missing})
+		// try to use SubmitAutoRests path if repo implements GameRepo
 		if gr, ok := repo.(GameRepo); ok {
-			_, _, err := SubmitAction(gr, gg.ID, p2.PlayerEmail, game.PendingActionRest, 0, actionTimeout)
+			emails := make([]string, len(missing))
+			for k, i := range missing {
+				emails[k] = gg.Players[i].PlayerEmail
+			}
+			_, _, err := SubmitAutoRests(gr, bus, gg.ID, emails, actionTimeout)
 			if err != nil {
 				logging.Error("SubmitAction auto-rest failed; falling back", err, nil)
 			}
 			return nil
 		}
 		// fallback inline
-		p2.HasSubmittedAction = true
-		p2.PendingActionType = game.PendingActionRest
-		p2.PendingActionEntityID = nil
-		engine.ResolveRound(gg)
+		for _, i := range missing {
+			gg.Players[i].HasSubmittedAction = true
+			gg.Players[i].PendingActionType = game.PendingActionRest
+			gg.Players[i].PendingActionEntityID = nil
+		}
+		round := resolveRound(gg, missing...)
+		finished := false
 		if gg.Status == game.StatusFinished {
 			if !gg.StatsCounted {
 				_ = repo.UpdateStatsOnGameEnd(gg, "")
 				gg.StatsCounted = true
+				finished = true
 			}
 		} else {
 			gg.ActionDeadline = time.Now().Add(actionTimeout)
 		}
-		return repo.UpdateGame(gg)
-	case !p1Submitted && p2Submitted:
-		logging.Info("auto-submitting rest for inactive player (p1)", nil)
-		if gr, ok := repo.(GameRepo); ok {
-			_, _, err := SubmitAction(gr, gg.ID, p1.PlayerEmail, game.PendingActionRest, 0, actionTimeout)
-			if err != nil {
-				logging.Error("SubmitAction auto-rest failed; falling back", err, nil)
-			}
-			return nil
+		if err := saveResolved(repo, bus, gg, round); err != nil {
+			return err
 		}
-		p1.HasSubmittedAction = true
-		p1.PendingActionType = game.PendingActionRest
-		p1.PendingActionEntityID = nil
-		engine.ResolveRound(gg)
-		if gg.Status == game.StatusFinished {
-			if !gg.StatsCounted {
-				_ = repo.UpdateStatsOnGameEnd(gg, "")
-				gg.StatsCounted = true
-			}
-		} else {
-			gg.ActionDeadline = time.Now().Add(actionTimeout)
+		if finished {
+			bus.Publish(events.NewGameFinished(gg.Clone(), events.FinishDecided, ""))
 		}
-		return repo.UpdateGame(gg)
+		return nil
 	default:
 		// shouldn't happen
 		return nil
 	}
 }
+
+// finishInactive saves gg, finished for inactivity, and publishes it.
+func finishInactive(repo interface{ UpdateGame(*game.Game) error }, bus *events.Bus, gg *game.Game) error {
+	if err := repo.UpdateGame(gg); err != nil {
+		return err
+	}
+	bus.Publish(events.NewGameFinished(gg.Clone(), events.FinishInactivity, ""))
+	return nil
+}
@@ -18,7 +18,7 @@ func (m *mockRepoTimeout) UpdateStatsOnGameEnd(g *game.Game, resignedEmail strin
 func TestHandleTimedOutGame_BothMiss(t *testing.T) {
 	g := &game.Game{Status: game.StatusInProgress, Phase: game.PhasePlanning, ActionDeadline: time.Now().Add(-time.Minute), Players: []game.Player{{PlayerName: "A"}, {PlayerName: "B"}}}
 	mr := &mockRepoTimeout{g: g}
-	if err := HandleTimedOutGame(mr, g, 1*time.Minute); err != nil {
+	if err := HandleTimedOutGame(mr, nil, g, 1*time.Minute); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if mr.g.Status != game.StatusFinished {
@@ -32,7 +32,7 @@ func TestHandleTimedOutGame_OneMiss(t *testing.T) {
 	p2 := game.Player{PlayerName: "B", PlayerEmail: "b@e.com", HasSubmittedAction: false, Hybrids: []game.Hybrid{{BaseHitPoints: 5, CurrentHitPoints: 5, IsActive: true}}}
 	g.Players = []game.Player{p1, p2}
 	mr := &mockRepoTimeout{g: g}
-	if err := HandleTimedOutGame(mr, g, 1*time.Minute); err != nil {
+	if err := HandleTimedOutGame(mr, nil, g, 1*time.Minute); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if !mr.g.Players[1].HasSubmittedAction {
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+// UpdateKind names a change of a game pushed to the clients watching it.
+type UpdateKind string
+
+const (
+	// UpdateGameChanged is any change without a more specific kind
+	// (a player joined or left, hybrids were created, ...).
+	UpdateGameChanged UpdateKind = "game_updated"
+	// UpdateGameStarted: the match moved to in_progress.
+	UpdateGameStarted UpdateKind = "game_started"
+	// UpdateActionSubmitted: the player at PlayerIndex submitted the
+	// round's action (the action itself stays secret).
+	UpdateActionSubmitted UpdateKind = "action_submitted"
+	// UpdateRoundResolved: round Round was resolved.
+	UpdateRoundResolved UpdateKind = "round_resolved"
+	// UpdateReserveEntered: another hybrid of the player at PlayerIndex
+	// entered the arena.
+	UpdateReserveEntered UpdateKind = "reserve_entered"
+	// UpdateGameFinished: the match is over.
+	UpdateGameFinished UpdateKind = "game_finished"
+)
+
+// Update is a change of a game. It only describes the change; subscribers
+// load the game itself to get the new state.
+type Update struct {
+	Kind   UpdateKind      `json:"type"`
+	GameID uint            `json:"-"`
+	Status game.GameStatus `json:"status"`
+	Round  int             `json:"round"`
+	// PlayerIndex is the player an action_submitted or reserve_entered
+	// update is about.
+	PlayerIndex *int `json:"player_index,omitempty"`
+}
+
+// subscriberBuffer is the number of updates kept for a slow subscriber;
+// further updates are dropped for it (the next one it gets still leads to
+// the latest state).
+const subscriberBuffer = 16
+
+// Publisher fans the updates of games out to subscribers. The updates are
+// derived from the domain events published on the bus.
+type Publisher struct {
+	mu   sync.Mutex
+	subs map[uint]map[chan Update]struct{}
+}
+
+// NewPublisher returns a publisher without subscribers that turns the
+// events published on b into updates.
+func NewPublisher(b *events.Bus) *Publisher {
+	p := &Publisher{subs: map[uint]map[chan Update]struct{}{}}
+	events.SubscribeAll(b, "updates", events.Async, p.publish)
+	return p
+}
+
+// Subscribe returns the updates of the game and a function that ends the
+// subscription and closes the channel.
+func (p *Publisher) Subscribe(gameID uint) (<-chan Update, func()) {
+	ch := make(chan Update, subscriberBuffer)
+	p.mu.Lock()
+	if p.subs[gameID] == nil {
+		p.subs[gameID] = map[chan Update]struct{}{}
+	}
+	p.subs[gameID][ch] = struct{}{}
+	p.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			p.mu.Lock()
+			delete(p.subs[gameID], ch)
+			if len(p.subs[gameID]) == 0 {
+				delete(p.subs, gameID)
+			}
+			p.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish sends the updates of e to the subscribers of its game.
+func (p *Publisher) publish(e events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.subs[e.GameID()]) == 0 {
+		return
+	}
+	for _, u := range updatesOf(e) {
+		for ch := range p.subs[e.GameID()] {
+			select {
+			case ch <- u:
+			default:
+			}
+		}
+	}
+}
+
+// updatesOf lists the updates e leads to, in the order they happened.
+// Events without a more specific update are reported as game_updated.
+func updatesOf(e events.Event) []Update {
+	var out []Update
+	switch ev := e.(type) {
+	case events.GameStarted:
+		out = append(out, Update{Kind: UpdateGameStarted})
+	case events.ActionSubmitted:
+		out = append(out, Update{Kind: UpdateActionSubmitted, PlayerIndex: intPtr(ev.PlayerIndex)})
+	case events.RoundResolved:
+		out = append(out, Update{Kind: UpdateRoundResolved, Round: ev.Round.RoundNumber})
+		for _, re := range ev.Round.Events {
+			if re.Type == game.EventReserveEntered {
+				out = append(out, Update{Kind: UpdateReserveEntered, PlayerIndex: intPtr(re.PlayerIndex)})
+			}
+		}
+	case events.GameFinished:
+		out = append(out, Update{Kind: UpdateGameFinished})
+	case events.PlayerResigned:
+		// The GameFinished event that follows reports the resignation.
+		return nil
+	default:
+		out = append(out, Update{Kind: UpdateGameChanged})
+	}
+	g := e.State()
+	for i := range out {
+		out[i].GameID, out[i].Status = g.ID, g.Status
+		if out[i].Round == 0 {
+			out[i].Round = g.RoundCount
+		}
+	}
+	return out
+}
+
+func intPtr(i int) *int { return &i }
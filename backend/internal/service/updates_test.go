@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ericogr/chimera-cards/internal/events"
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func drain(ch <-chan Update) []Update {
+	var out []Update
+	for {
+		select {
+		case u := <-ch:
+			out = append(out, u)
+		default:
+			return out
+		}
+	}
+}
+
+func kinds(us []Update) []UpdateKind {
+	out := make([]UpdateKind, len(us))
+	for i, u := range us {
+		out[i] = u.Kind
+	}
+	return out
+}
+
+func TestPublisher_UpdatesFromServiceEvents(t *testing.T) {
+	b := events.NewBus()
+	p := NewPublisher(b)
+	ch, stop := p.Subscribe(7)
+	defer stop()
+	other, stopOther := p.Subscribe(8)
+	defer stopOther()
+
+	g := decidedInOneRound()
+	g.ID = 7
+	mr := &mockRepoSA{games: map[uint]*game.Game{7: g}}
+	if _, _, err := SubmitAction(mr, b, 7, "p2@example.com", game.PendingActionBasicAttack, 0, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := SubmitAction(mr, b, 7, "p1@example.com", game.PendingActionBasicAttack, 0, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.Close() // wait for the publisher to handle the events
+
+	us := drain(ch)
+	if k := kinds(us); len(k) != 3 || k[0] != UpdateActionSubmitted || k[1] != UpdateRoundResolved || k[2] != UpdateGameFinished {
+		t.Fatalf("expected action_submitted, round_resolved and game_finished, got %v", k)
+	}
+	if us[0].PlayerIndex == nil || *us[0].PlayerIndex != 1 || us[1].Round != 1 || us[2].Status != game.StatusFinished {
+		t.Fatalf("unexpected updates: %+v", us)
+	}
+	if len(drain(other)) != 0 {
+		t.Fatalf("expected no updates for another game")
+	}
+}
+
+func TestUpdatesOf(t *testing.T) {
+	g := &game.Game{Status: game.StatusInProgress, RoundCount: 4}
+	g.ID = 3
+	r := &game.GameRound{RoundNumber: 3, Events: []game.RoundEvent{
+		{Type: game.EventHybridDefeated, PlayerIndex: 0},
+		{Type: game.EventReserveEntered, PlayerIndex: 0},
+	}}
+	us := updatesOf(events.NewRoundResolved(g, r))
+	if k := kinds(us); len(k) != 2 || k[0] != UpdateRoundResolved || k[1] != UpdateReserveEntered {
+		t.Fatalf("expected round_resolved then reserve_entered, got %v", k)
+	}
+	if us[0].Round != 3 || us[0].GameID != 3 || *us[1].PlayerIndex != 0 {
+		t.Fatalf("unexpected updates: %+v %+v", us[0], us[1])
+	}
+
+	if k := kinds(updatesOf(events.NewPlayerLeft(g, 1))); len(k) != 1 || k[0] != UpdateGameChanged {
+		t.Fatalf("expected a generic update for a player leaving, got %v", k)
+	}
+	if us := updatesOf(events.NewPlayerResigned(g, 1)); len(us) != 0 {
+		t.Fatalf("expected the resignation to be reported by game_finished, got %v", us)
+	}
+}
+
+func TestPublisher_ForgetsGamesWithoutSubscribers(t *testing.T) {
+	p := NewPublisher(nil)
+	ch, stop := p.Subscribe(2)
+	stop()
+	stop()
+	if len(p.subs) != 0 {
+		t.Fatalf("expected the game to be forgotten with its last subscriber")
+	}
+	if _, ok := <-ch; ok {
+		t.Fatalf("expected the channel to be closed")
+	}
+}
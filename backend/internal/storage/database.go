@@ -33,7 +33,7 @@ func OpenDB(dataSourceName string, entitiesFromConfig []game.Entity) (*gorm.DB,
 	// error and let the operator recreate the DB. If no core tables are
 	// present, create the initial schema from the current models.
 	migrator := db.Migrator()
-	coreModels := []interface{}{&game.Entity{}, &game.Hybrid{}, &game.Player{}, &game.Game{}, &game.User{}, &game.HybridGeneratedName{}}
+	coreModels := []interface{}{&game.Entity{}, &game.Hybrid{}, &game.Player{}, &game.Game{}, &game.User{}, &game.HybridGeneratedName{}, &game.GameRound{}, &game.StatusEffect{}}
 	present := 0
 	for _, m := range coreModels {
 		if migrator.HasTable(m) {
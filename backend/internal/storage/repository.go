@@ -13,6 +13,11 @@ type Repository interface {
 	GetGameByID(id uint) (*game.Game, error)
 	FindGameByJoinCode(code string) (*game.Game, error)
 	UpdateGame(g *game.Game) error
+	// UpdateGameWithRound saves the game and appends the history record of
+	// the round that was just resolved in a single transaction.
+	UpdateGameWithRound(g *game.Game, r *game.GameRound) error
+	// GetGameRounds returns the recorded rounds of a game in order.
+	GetGameRounds(gameID uint) ([]game.GameRound, error)
 	GetEntitiesByIDs(ids []uint) ([]game.Entity, error)
 	// SaveEntityImage stores a PNG blob for the given entity ID.
 	SaveEntityImage(entityID uint, pngBytes []byte) error
@@ -54,7 +54,7 @@ func (r *sqliteRepository) CreateGame(g *game.Game) error {
 func (r *sqliteRepository) GetGameByID(id uint) (*game.Game, error) {
 	var g game.Game
 
-	err := r.db.Preload("Players.Hybrids.BaseEntities").First(&g, id).Error
+	err := r.db.Preload("Players.Hybrids.BaseEntities").Preload("Players.Hybrids.StatusEffects").First(&g, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +92,56 @@ func (r *sqliteRepository) GetGameByID(id uint) (*game.Game, error) {
 }
 
 func (r *sqliteRepository) UpdateGame(g *game.Game) error {
-	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(g).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveGame(tx, g)
+	})
+}
+
+func (r *sqliteRepository) UpdateGameWithRound(g *game.Game, round *game.GameRound) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveGame(tx, g); err != nil {
+			return err
+		}
+		round.GameID = g.ID
+		return tx.Create(round).Error
+	})
+}
+
+// saveGame persists the game with all its associations. Saving only
+// inserts and updates children, so status effects that expired (were
+// removed from a hybrid's list) are deleted explicitly.
+func saveGame(tx *gorm.DB, g *game.Game) error {
+	if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(g).Error; err != nil {
+		return err
+	}
+	for pi := range g.Players {
+		for hi := range g.Players[pi].Hybrids {
+			h := &g.Players[pi].Hybrids[hi]
+			if h.ID == 0 {
+				continue
+			}
+			q := tx.Unscoped().Where("hybrid_id = ?", h.ID)
+			if len(h.StatusEffects) > 0 {
+				keep := make([]uint, 0, len(h.StatusEffects))
+				for _, e := range h.StatusEffects {
+					keep = append(keep, e.ID)
+				}
+				q = q.Where("id NOT IN ?", keep)
+			}
+			if err := q.Delete(&game.StatusEffect{}).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *sqliteRepository) GetGameRounds(gameID uint) ([]game.GameRound, error) {
+	var rounds []game.GameRound
+	if err := r.db.Where("game_id = ?", gameID).Order("round_number asc").Find(&rounds).Error; err != nil {
+		return nil, err
+	}
+	return rounds, nil
 }
 
 func (r *sqliteRepository) GetEntitiesByIDs(ids []uint) ([]game.Entity, error) {
@@ -132,7 +181,7 @@ func (r *sqliteRepository) FindTimedOutGames(now time.Time) ([]game.Game, error)
 	var games []game.Game
 	// Find games that are in progress, in planning phase and whose
 	// action_deadline has passed.
-	if err := r.db.Preload("Players.Hybrids.BaseEntities").Where("status = ? AND phase = ? AND action_deadline IS NOT NULL AND action_deadline <= ?", game.StatusInProgress, game.PhasePlanning, now).Find(&games).Error; err != nil {
+	if err := r.db.Preload("Players.Hybrids.BaseEntities").Preload("Players.Hybrids.StatusEffects").Where("status = ? AND phase = ? AND action_deadline IS NOT NULL AND action_deadline <= ?", game.StatusInProgress, game.PhasePlanning, now).Find(&games).Error; err != nil {
 		return nil, err
 	}
 	return games, nil
@@ -218,11 +267,11 @@ func (r *sqliteRepository) RemovePlayerByEmail(gameID uint, playerEmail string)
 
 func (r *sqliteRepository) UpdateStatsOnGameEnd(g *game.Game, resignedEmail string) error {
 	// Helper to upsert and add deltas
-	upsert := func(email, name string, played, wins, resigns int) error {
+	upsert := func(email, name string, played, wins, draws, resigns int) error {
 		var ps game.User
 		if err := r.db.Where("email = ?", email).First(&ps).Error; err != nil {
 			if err == gorm.ErrRecordNotFound {
-				ps = game.User{Email: email, PlayerName: name, GamesPlayed: 0, Wins: 0, Resignations: 0}
+				ps = game.User{Email: email, PlayerName: name, GamesPlayed: 0, Wins: 0, Draws: 0, Resignations: 0}
 			} else {
 				return err
 			}
@@ -234,40 +283,36 @@ func (r *sqliteRepository) UpdateStatsOnGameEnd(g *game.Game, resignedEmail stri
 		}
 		ps.GamesPlayed += played
 		ps.Wins += wins
+		ps.Draws += draws
 		ps.Resignations += resigns
 		return r.db.Save(&ps).Error
 	}
-	if len(g.Players) != 2 {
+	if len(g.Players) < 2 {
 		return nil
 	}
-	p1 := g.Players[0]
-	p2 := g.Players[1]
-	// everyone played one game
-	if err := upsert(p1.PlayerEmail, p1.PlayerName, 1, 0, 0); err != nil {
-		return err
-	}
-	if err := upsert(p2.PlayerEmail, p2.PlayerName, 1, 0, 0); err != nil {
-		return err
-	}
-	// winner
-	if g.Winner != "" {
-		if p1.PlayerName == g.Winner {
-			if err := upsert(p1.PlayerEmail, p1.PlayerName, 0, 1, 0); err != nil {
-				return err
-			}
-		} else if p2.PlayerName == g.Winner {
-			if err := upsert(p2.PlayerEmail, p2.PlayerName, 0, 1, 0); err != nil {
-				return err
-			}
+	// everyone played one game; every player of the winning side wins and
+	// everyone draws a drawn match. Bots are not tracked.
+	for i, p := range g.Players {
+		if p.IsBot() {
+			continue
+		}
+		wins, draws := 0, 0
+		if g.IsWinner(i) {
+			wins = 1
+		}
+		if g.Draw {
+			draws = 1
+		}
+		if err := upsert(p.PlayerEmail, p.PlayerName, 1, wins, draws, 0); err != nil {
+			return err
 		}
 	}
 	// resignation
 	if resignedEmail != "" {
-		if p1.PlayerEmail == resignedEmail {
-			return upsert(p1.PlayerEmail, p1.PlayerName, 0, 0, 1)
-		}
-		if p2.PlayerEmail == resignedEmail {
-			return upsert(p2.PlayerEmail, p2.PlayerName, 0, 0, 1)
+		for _, p := range g.Players {
+			if p.PlayerEmail == resignedEmail {
+				return upsert(p.PlayerEmail, p.PlayerName, 0, 0, 0, 1)
+			}
 		}
 	}
 	return nil
@@ -277,7 +322,7 @@ func (r *sqliteRepository) GetStatsByEmail(email string) (*game.User, error) {
 	var ps game.User
 	if err := r.db.Where("email = ?", email).First(&ps).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return &game.User{Email: email, GamesPlayed: 0, Wins: 0, Resignations: 0}, nil
+			return &game.User{Email: email, GamesPlayed: 0, Wins: 0, Draws: 0, Resignations: 0}, nil
 		}
 		return nil, err
 	}
@@ -305,7 +350,8 @@ func (r *sqliteRepository) UpsertUser(email, name string) error {
 	return r.db.Save(&u).Error
 }
 
-// GetTopPlayers returns top N players ordered by Wins desc, then GamesPlayed desc
+// GetTopPlayers returns top N players ordered by Wins desc, then Draws desc,
+// then GamesPlayed desc
 func (r *sqliteRepository) GetTopPlayers(limit int) ([]game.User, error) {
 	if limit <= 0 {
 		limit = 10
@@ -313,6 +359,7 @@ func (r *sqliteRepository) GetTopPlayers(limit int) ([]game.User, error) {
 	var users []game.User
 	if err := r.db.Model(&game.User{}).
 		Order("wins DESC").
+		Order("draws DESC").
 		Order("games_played DESC").
 		Limit(limit).
 		Find(&users).Error; err != nil {
@@ -0,0 +1,81 @@
+// Package view projects games for the person looking at them. Players
+// choose their actions in secret and keep their reserves hidden until
+// they enter the arena (see game.md), so the state sent to a client must
+// only contain what that client is allowed to know: its own side in full,
+// and for everyone else what has been revealed in the arena.
+package view
+
+import "github.com/ericogr/chimera-cards/internal/game"
+
+// Spectator is the player index of a viewer who does not play in the
+// game.
+const Spectator = -1
+
+// ViewerIndex returns the index of the player with email in g, or
+// Spectator.
+func ViewerIndex(g *game.Game, email string) int {
+	if email == "" {
+		return Spectator
+	}
+	for i := range g.Players {
+		if g.Players[i].PlayerEmail == email {
+			return i
+		}
+	}
+	return Spectator
+}
+
+// ForEmail returns the projection of g for the user with email.
+func ForEmail(g *game.Game, email string) *game.Game {
+	return ForPlayer(g, ViewerIndex(g, email))
+}
+
+// ForPlayer returns a copy of g as seen by the player at idx (Spectator
+// for someone outside the game). The viewer and their teammates are shown
+// in full. For the other players, the actions chosen for the round being
+// planned and the reserve picked to replace a fallen hybrid are removed,
+// and hybrids that have not entered the arena yet are replaced by blank
+// placeholders that only keep their position. Whether a player has
+// submitted stays visible. Once the match is finished nothing is secret.
+// g is not modified.
+func ForPlayer(g *game.Game, idx int) *game.Game {
+	out := g.Clone()
+	if g.Status == game.StatusFinished {
+		return out
+	}
+	for i := range out.Players {
+		if idx != Spectator && (i == idx || g.TeamOf(i) == g.TeamOf(idx)) {
+			continue
+		}
+		p := &out.Players[i]
+		p.PendingActionType = game.PendingActionNone
+		p.PendingActionEntityID = nil
+		p.PendingSwitchIndex = nil
+		p.PendingTargetIndex = nil
+		p.NextReserveIndex = nil
+		for j := range p.Hybrids {
+			if !p.Hybrids[j].Revealed {
+				p.Hybrids[j] = hidden(p.Hybrids[j])
+			}
+		}
+	}
+	return out
+}
+
+// ForEmailAll projects every game in gs for the user with email.
+func ForEmailAll(gs []game.Game, email string) []game.Game {
+	out := make([]game.Game, len(gs))
+	for i := range gs {
+		out[i] = *ForEmail(&gs[i], email)
+	}
+	return out
+}
+
+// hidden is the placeholder shown for an unrevealed hybrid: its record ID
+// (so clients can key lists) and nothing about its build or state.
+func hidden(h game.Hybrid) game.Hybrid {
+	var p game.Hybrid
+	p.ID = h.ID
+	p.PlayerID = h.PlayerID
+	return p
+}
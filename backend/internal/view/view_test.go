@@ -0,0 +1,66 @@
+package view
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ericogr/chimera-cards/internal/game"
+)
+
+func planningGame() *game.Game {
+	id := uint(7)
+	sw := 1
+	g := &game.Game{Status: game.StatusInProgress, Phase: game.PhasePlanning, Players: []game.Player{
+		{PlayerName: "A", PlayerEmail: "a@example.com", HasSubmittedAction: true, PendingActionType: game.PendingActionAbility, PendingActionEntityID: &id, NextReserveIndex: &sw,
+			Hybrids: []game.Hybrid{{Name: "HA1", CurrentHitPoints: 9, IsActive: true, Revealed: true}, {Name: "HA2", CurrentHitPoints: 12}}},
+		{PlayerName: "B", PlayerEmail: "b@example.com", HasSubmittedAction: true, PendingActionType: game.PendingActionSwitch, PendingSwitchIndex: &sw,
+			Hybrids: []game.Hybrid{{Name: "HB1", CurrentHitPoints: 8, IsActive: true, Revealed: true}, {Name: "HB2", CurrentHitPoints: 11}}},
+	}}
+	g.Players[1].Hybrids[1].ID = 42
+	return g
+}
+
+func TestForEmail_HidesOpponentSecrets(t *testing.T) {
+	g := planningGame()
+	before := g.Clone()
+	v := ForEmail(g, "a@example.com")
+
+	if !reflect.DeepEqual(g, before) {
+		t.Fatalf("the projection modified the game")
+	}
+	if !reflect.DeepEqual(v.Players[0], g.Players[0]) {
+		t.Fatalf("the viewer must see their own player in full")
+	}
+	b := v.Players[1]
+	if b.PendingActionType != game.PendingActionNone || b.PendingSwitchIndex != nil || !b.HasSubmittedAction {
+		t.Fatalf("expected B's choice hidden but its submission visible, got %+v", b)
+	}
+	if b.Hybrids[0].Name != "HB1" || b.Hybrids[0].CurrentHitPoints != 8 {
+		t.Fatalf("the hybrid in the arena must stay visible, got %+v", b.Hybrids[0])
+	}
+	if len(b.Hybrids) != 2 || b.Hybrids[1].Name != "" || b.Hybrids[1].CurrentHitPoints != 0 || b.Hybrids[1].ID != 42 {
+		t.Fatalf("expected a blank placeholder for B's reserve, got %+v", b.Hybrids[1])
+	}
+}
+
+func TestForPlayer_SpectatorsTeamsAndFinishedGames(t *testing.T) {
+	g := planningGame()
+	s := ForEmail(g, "someone@example.com")
+	for i, p := range s.Players {
+		if p.PendingActionType != game.PendingActionNone || p.NextReserveIndex != nil || p.Hybrids[1].Name != "" {
+			t.Fatalf("spectators must not see player %d's secrets, got %+v", i, p)
+		}
+	}
+
+	g.Mode = game.ModeTeams
+	g.Players[1].Team = 0
+	if v := ForPlayer(g, 0); v.Players[1].PendingActionType != game.PendingActionSwitch || v.Players[1].Hybrids[1].Name != "HB2" {
+		t.Fatalf("teammates must see each other's choices and reserves")
+	}
+
+	g = planningGame()
+	g.Status = game.StatusFinished
+	if v := ForPlayer(g, Spectator); !reflect.DeepEqual(v, g) {
+		t.Fatalf("nothing is secret once the match is finished")
+	}
+}
@@ -7,23 +7,30 @@ import iconDefend from './images/defend.svg';
 import iconRest from './images/rest.svg';
 import iconAbility from './images/ability.svg';
 import iconEnd from './images/end_match.svg';
-import { Player, Hybrid, Entity, EntityName } from './types';
+import { Player, Hybrid, Entity, EntityName, StatusEffect } from './types';
 import { hybridAssetUrlFromNames } from './utils/keys';
+import { enemiesInPlay, maxPlayers, teamOf } from './utils/teams';
 import { apiFetch } from './api';
 import * as constants from './constants';
 import { safeRemoveLocal } from './runtimeConfig';
 import { useGame } from './hooks/useGame';
+import { useRules } from './hooks/useRules';
 import { Button, IconButton } from './ui';
 
 const GameBoard: React.FC = () => {
   const { gameCode } = useParams<{ gameCode: string }>();
   const navigate = useNavigate();
   const { game, error: gameError } = useGame(gameCode, 3000);
+  const rules = useRules();
   const [timeLeft, setTimeLeft] = useState<number | null>(null);
   const [submitting, setSubmitting] = useState(false);
   const [leftWins, setLeftWins] = useState<number | null>(0);
   const [rightWins, setRightWins] = useState<number | null>(0);
   const [lockedRound, setLockedRound] = useState<number | null>(null);
+  // Enemy player chosen as target in team games (null = first enemy in play)
+  const [targetIndex, setTargetIndex] = useState<number | null>(null);
+  // Suggested action for the round it was requested in (practice games)
+  const [hint, setHint] = useState<{ round: number; text: string } | null>(null);
   const actingRef = useRef(false);
   const endRef = useRef(false);
   const prevStatusRef = useRef<string | undefined>(undefined);
@@ -60,15 +67,17 @@ const GameBoard: React.FC = () => {
     }
   }, [game, lockedRound]);
 
-  // Compute match-local score: number of opponent hybrids defeated.
+  // Compute match-local score: number of opposing hybrids defeated by
+  // each side (team 1 on the left).
   useEffect(() => {
     if (!game) return;
-    const p1 = game.players?.[0];
-    const p2 = game.players?.[1];
-    const left = p2?.hybrids?.filter(h => !!(h as any).is_defeated).length ?? 0;
-    const right = p1?.hybrids?.filter(h => !!(h as any).is_defeated).length ?? 0;
-    setLeftWins(left);
-    setRightWins(right);
+    const defeatedOf = (team: number) =>
+      (game.players || []).reduce(
+        (n, p, i) => (teamOf(game, i) === team ? n + (p.hybrids?.filter(h => !!(h as any).is_defeated).length ?? 0) : n),
+        0,
+      );
+    setLeftWins(defeatedOf(1));
+    setRightWins(defeatedOf(0));
   }, [game]);
 
   useEffect(() => {
@@ -90,12 +99,18 @@ const GameBoard: React.FC = () => {
     return <div className="game-board-loading">Loading game...</div>;
   }
 
-  const [player1, player2] = game.players;
-  const me: Player | undefined = game.players.find(p => (p.player_email || '') === playerEmail);
-  const opponent: Player | undefined = game.players.find(p => (p.player_email || '') !== playerEmail);
+  const seats = Array.from({ length: maxPlayers(game) }, (_, i) => game.players[i]);
+  const myIndex = game.players.findIndex(p => (p.player_email || '') === playerEmail);
+  const me: Player | undefined = myIndex >= 0 ? game.players[myIndex] : undefined;
+  const others: Player[] = game.players.filter((_, i) => i !== myIndex);
+  const targets = myIndex >= 0 ? enemiesInPlay(game, myIndex) : [];
+  // Games with a bot are practice games, where hints are available.
+  const practice = game.players.some(p => !!p.bot_difficulty);
   const myActive: Hybrid | undefined = me?.hybrids?.find(h => h.is_active && !h.is_defeated);
   const planning = game.status === 'in_progress' && game.phase === 'planning';
   const myTurn = planning && !me?.has_submitted_action;
+  const isStunned = (h?: Hybrid) => activeEffects(h, game.round_count).some(e => e.kind === 'stun');
+  const myStunned = planning && isStunned(myActive);
 
   const submittedLabel = (p?: Player) => (p?.has_submitted_action ? 'Submitted' : 'Waiting');
 
@@ -128,10 +143,14 @@ const GameBoard: React.FC = () => {
     if (me.pending_action_type === 'basic_attack') return 'Basic Attack';
     if (me.pending_action_type === 'defend') return 'Defend';
     if (me.pending_action_type === 'rest') return 'Rest';
+    if (me.pending_action_type === 'switch') {
+      const next = me.pending_switch_index != null ? me.hybrids[me.pending_switch_index] : undefined;
+      return next ? `Switch to ${next.generated_name || next.name}` : 'Switch';
+    }
     return '';
   };
 
-  const submitAction = async (action_type: 'basic_attack' | 'defend' | 'ability' | 'rest', entity?: Entity) => {
+  const submitAction = async (action_type: 'basic_attack' | 'defend' | 'ability' | 'rest' | 'switch', entity?: Entity, hybrid_index?: number) => {
     try {
       if (actingRef.current || submitting || me?.has_submitted_action) return;
       actingRef.current = true;
@@ -142,7 +161,12 @@ const GameBoard: React.FC = () => {
       const res = await apiFetch(`${constants.API_GAMES}/${gameCode}/action`, {
         method: 'POST',
         headers: { [constants.HEADER_CONTENT_TYPE]: constants.CONTENT_TYPE_JSON },
-        body: JSON.stringify({ action_type, entity_id: entity?.ID }),
+        body: JSON.stringify({
+          action_type,
+          entity_id: entity?.ID,
+          hybrid_index,
+          target_index: targetIndex != null && targets.includes(targetIndex) ? targetIndex : undefined,
+        }),
       });
       if (!res.ok) throw new Error(await res.text());
     } catch (e: any) {
@@ -150,7 +174,41 @@ const GameBoard: React.FC = () => {
     } finally { /* keep locked until next round */ }
   };
 
-  
+  const requestHint = async () => {
+    try {
+      const res = await apiFetch(`${constants.API_GAMES}/${gameCode}/hint`);
+      const data = await res.json();
+      if (!res.ok) throw new Error(data.error || 'No hint available');
+      const a = data.action || {};
+      const hybrids = me?.hybrids || [];
+      const entityName = hybrids.flatMap(h => h.base_entities || []).find(e => e.ID === a.entity_id)?.skill?.name;
+      let text = String(a.action_type).replace('_', ' ');
+      if (a.action_type === 'ability' && entityName) text = `ability: ${entityName}`;
+      if (a.action_type === 'switch' && a.hybrid_index != null) text = `switch to ${hybrids[a.hybrid_index]?.generated_name || hybrids[a.hybrid_index]?.name}`;
+      if (a.target_index != null) text += ` on ${game?.players[a.target_index]?.player_name}`;
+      setHint({ round: game?.round_count ?? 0, text: `${text} (${Math.round(((data.value ?? 0) + 1) * 50)}% expected)` });
+    } catch (e: any) {
+      alert(`Hint error: ${e.message}`);
+    }
+  };
+
+  const setNextReserve = async (hybrid_index: number | null) => {
+    try {
+      const res = await apiFetch(`${constants.API_GAMES}/${gameCode}/next-reserve`, {
+        method: 'POST',
+        headers: { [constants.HEADER_CONTENT_TYPE]: constants.CONTENT_TYPE_JSON },
+        body: JSON.stringify({ hybrid_index }),
+      });
+      if (!res.ok) throw new Error(await res.text());
+    } catch (e: any) {
+      alert(`Reserve error: ${e.message}`);
+    }
+  };
+
+  // Reserves that can still enter the arena, with their index in me.hybrids.
+  const myReserves = (me?.hybrids || [])
+    .map((h, idx) => ({ h, idx }))
+    .filter(({ h }) => !h.is_active && !h.is_defeated);
 
   return (
     <div className="game-board-container">
@@ -177,42 +235,35 @@ const GameBoard: React.FC = () => {
         </div>
 
         <div className="game-board-main">
-        <div className="player-area player-one">
-          <h2>
-            <span className="player-name" title={player1?.player_name || ''}>
-              {player1?.player_name || 'Waiting for Player 1'}
-            </span>
-          </h2>
-          {player1 && (
-            <div>
-              <Stats hybrid={player1.hybrids?.find(h => h.is_active)} isMe={(player1.player_email || '') === playerEmail} />
-            </div>
-          )}
-        </div>
-        <div className="player-area player-two">
-          <h2>
-            <span className="player-name" title={player2?.player_name || ''}>
-              {player2?.player_name || 'Waiting for Player 2'}
-            </span>
-          </h2>
-          {player2 && (
-            <div>
-              <Stats hybrid={player2.hybrids?.find(h => h.is_active)} isMe={(player2.player_email || '') === playerEmail} />
-            </div>
-          )}
-        </div>
+        {seats.map((p, idx) => (
+          <div key={p?.ID ?? `seat-${idx}`} className={`player-area ${teamOf(game, idx) === 0 ? 'player-one' : 'player-two'}`}>
+            <h2>
+              <span className="player-name" title={p?.player_name || ''}>
+                {p?.player_name || `Waiting for Player ${idx + 1}`}
+              </span>
+            </h2>
+            {p && (
+              <div>
+                <Stats hybrid={p.hybrids?.find(h => h.is_active)} isMe={(p.player_email || '') === playerEmail} round={game.round_count} />
+              </div>
+            )}
+          </div>
+        ))}
         </div>
       </main>
 
       <footer className="game-board-footer">
         <div>Round: {game.round_count} | Phase: {game.phase || '-'} | {myTurn ? 'Choose your action' : planning ? 'Waiting opponent/you' : 'Resolving...'}</div>
         <div className="muted small mt-6">
-          Your action: {submittedLabel(me)} | Opponent action: {submittedLabel(opponent)}
+          Your action: {submittedLabel(me)} |{' '}
+          {others.length === 1
+            ? `Opponent action: ${submittedLabel(others[0])}`
+            : others.map(p => `${p.player_name}: ${submittedLabel(p)}`).join(' | ')}
         </div>
         {/* End Match moved to the bottom as a final action with consistent layout */}
         {game.status === 'finished' && (
           <div className="row-center">
-            <div>Winner: {game.winner === '' || game.winner == null ? 'None' : game.winner}</div>
+            <div>{game.draw ? 'Result: Draw' : `Winner: ${game.winner === '' || game.winner == null ? 'None' : game.winner}`}</div>
               <Button
               variant="ghost"
               onClick={() => {
@@ -226,44 +277,98 @@ const GameBoard: React.FC = () => {
         )}
         {myTurn && myActive && (
           <div className="action-panel mt-12">
+            {practice && (
+              <div className="action-row">
+                <Button onClick={requestHint} disabled={submitting}>Hint</Button>
+                {hint && hint.round === game.round_count && <div className="action-desc">Suggested: {hint.text}</div>}
+              </div>
+            )}
+            {targets.length > 1 && (
+              <div className="action-row">
+                <label htmlFor="actionTarget">Target: </label>
+                <select
+                  id="actionTarget"
+                  value={targetIndex != null && targets.includes(targetIndex) ? targetIndex : ''}
+                  onChange={(e) => setTargetIndex(e.target.value === '' ? null : Number(e.target.value))}
+                >
+                  <option value="">First enemy in play</option>
+                  {targets.map(i => (
+                    <option key={game.players[i].ID} value={i}>{game.players[i].player_name}</option>
+                  ))}
+                </select>
+                <div className="action-desc">Attacks and abilities are aimed at this enemy.</div>
+              </div>
+            )}
             <div className="action-row">
               <IconButton icon={iconAttack} onClick={() => submitAction('basic_attack')} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null}>
                 Basic Attack
               </IconButton>
-              <div className="action-desc">Perform a basic attack with your active hybrid.</div>
+              <div className="action-desc">Perform a basic attack with your active hybrid. Spends 1 VIG; with 0 VIG it deals {Math.round(rules.zero_vigor_multiplier * 100)}% damage.</div>
             </div>
             <div className="action-row">
               <IconButton icon={iconDefend} onClick={() => submitAction('defend')} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null}>
                 Defend
               </IconButton>
-              <div className="action-desc">Increase defense this round. Spends VIG if available.</div>
+              <div className="action-desc">Increase defense by {Math.round((rules.defend_multiplier - 1) * 100)}% this round. Spends 1 VIG; no bonus with 0 VIG.</div>
             </div>
             {(() => {
               const selId = myActive.selected_ability_entity_id;
-              const ability = myActive.base_entities?.find(a => a.ID === selId) as Entity | undefined;
-              if (!ability) return null;
-              const notEnoughEnergy = (myActive?.current_ene || 0) < (ability.skill?.cost || 0);
+              const primary = myActive.base_entities?.find(a => a.ID === selId) as Entity | undefined;
+              // In all-abilities mode every base entity's skill is listed,
+              // primary first; the others pay the Energy surcharge.
+              const abilities = rules.abilities?.all_usable
+                ? [...(primary ? [primary] : []), ...(myActive.base_entities || []).filter(a => a.ID !== selId && a.skill)]
+                : (primary ? [primary] : []);
+              return abilities.map((ability) => {
+              const surcharge = ability.ID !== selId ? (rules.abilities?.secondary_energy_surcharge || 0) : 0;
+              const cost = (ability.skill?.cost || 0) + surcharge;
+              const notEnoughEnergy = (myActive?.current_ene || 0) < cost;
+              const cooldown = myActive.ability_cooldowns?.[String(ability.ID)] || 0;
             return (
-                <div className="action-row">
-                  <IconButton icon={iconAbility} onClick={() => submitAction('ability', ability)} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null || notEnoughEnergy}>
+                <div className="action-row" key={ability.ID}>
+                  <IconButton icon={iconAbility} onClick={() => submitAction('ability', ability)} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null || notEnoughEnergy || cooldown > 0}>
                     {ability.skill?.name}
                   </IconButton>
                   <div className="action-desc">
-                    {ability.skill?.description} — ENE {ability.skill?.cost}, VIG {vigCostFor(ability.name)}
+                    {ability.skill?.description} — ENE {cost}{surcharge > 0 && ` (+${surcharge} secondary)`}, VIG {vigCostFor(ability.name)}
+                    {ability.skill?.cooldown ? `, cooldown ${ability.skill.cooldown}` : ''}
+                    {cooldown > 0 && ` (available in ${cooldown} round(s))`}
                   </div>
                 </div>
               );
+              });
             })()}
             <div className="action-row">
               <IconButton icon={iconRest} onClick={() => submitAction('rest')} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null}>
                 Rest
               </IconButton>
-              <div className="action-desc">Recover +2 VIG and +2 ENE.</div>
+              <div className="action-desc">Recover +{rules.rest_vigor} VIG and +{rules.rest_energy} ENE.</div>
             </div>
+            {me?.hybrids.map((h, idx) => {
+              if (h.is_active || h.is_defeated) return null;
+              return (
+                <div className="action-row" key={h.ID}>
+                  <IconButton onClick={() => submitAction('switch', undefined, idx)} disabled={submitting || !!me?.has_submitted_action || lockedRound !== null}>
+                    Switch to {h.generated_name || h.name}
+                  </IconButton>
+                  <div className="action-desc">
+                    {h.revealed
+                      ? `Returns with PV ${h.current_pv}/${h.base_pv}. The incoming hybrid takes the opponent's action this round.`
+                      : "Enters with full stats. The incoming hybrid takes the opponent's action this round."}
+                  </div>
+                </div>
+              );
+            })}
           </div>
         )}
         {!myTurn && planning && (
-          <div className="mt-8">{me?.has_submitted_action ? 'You already chose. Waiting for opponent...' : 'Waiting for both actions...'}</div>
+          <div className="mt-8">
+            {myStunned
+              ? `Your hybrid is stunned and skips this round. Waiting for ${others.length > 1 ? 'the other players' : 'opponent'}...`
+              : me?.has_submitted_action
+              ? `You already chose. Waiting for ${others.length > 1 ? 'the other players' : 'opponent'}...`
+              : 'Waiting for all actions...'}
+          </div>
         )}
         
 
@@ -303,6 +408,21 @@ const GameBoard: React.FC = () => {
             Your choice: {currentActionLabel() || '—'}
           </div>
         )}
+        {planning && myReserves.length > 1 && (
+          <div className="muted small mt-8">
+            <label htmlFor="nextReserve">Next reserve if your hybrid falls: </label>
+            <select
+              id="nextReserve"
+              value={me?.next_reserve_index ?? ''}
+              onChange={(e) => setNextReserve(e.target.value === '' ? null : Number(e.target.value))}
+            >
+              <option value="">First available</option>
+              {myReserves.map(({ h, idx }) => (
+                <option key={h.ID} value={idx}>{h.generated_name || h.name}</option>
+              ))}
+            </select>
+          </div>
+        )}
 
         {game.last_round_summary && (
           <div className="panel-dark">
@@ -317,14 +437,45 @@ const GameBoard: React.FC = () => {
 
 export default GameBoard;
 
-const Stats: React.FC<{ hybrid?: Hybrid; isMe: boolean }> = ({ hybrid, isMe }) => {
+const activeEffects = (h: Hybrid | undefined, round: number): StatusEffect[] =>
+  (h?.status_effects || []).filter(e => e.remaining_rounds > 0 && e.start_round <= round);
+
+const effectLabel = (e: StatusEffect): string => {
+  switch (e.kind) {
+    case 'attack_buff': return `ATK +${e.magnitude}%`;
+    case 'attack_debuff': return `ATK -${e.magnitude}%`;
+    case 'agility_debuff': return `AGI -${e.magnitude}%`;
+    case 'defense_multiplier': return `DEF x${e.magnitude}`;
+    case 'ignore_defense': return `Ignores ${e.magnitude}% DEF`;
+    case 'agility_damage': return `+1 ATK / ${e.magnitude} AGI`;
+    case 'cannot_attack': return 'Cannot attack';
+    case 'stun': return 'Stunned';
+    case 'damage_over_time': return `-${e.magnitude} HP/round`;
+    case 'heal_over_time': return `+${e.magnitude} HP/round`;
+    case 'damage_bonus': return `DMG +${e.magnitude}%`;
+    case 'reflect': return `Reflects ${e.magnitude}%`;
+    default: return e.kind;
+  }
+};
+
+const Stats: React.FC<{ hybrid?: Hybrid; isMe: boolean; round: number }> = ({ hybrid, isMe, round }) => {
   if (!hybrid) return <div />;
+  const effects = activeEffects(hybrid, round);
+  const upcoming = (hybrid.status_effects || []).filter(e => e.remaining_rounds > 0 && e.start_round > round);
 
   const imgSrc = hybridAssetUrlFromNames((hybrid?.base_entities || []).map(a => a.name));
 
   return (
     <div className="hybrid-card">
       <div className="hybrid-name">{hybrid.generated_name || hybrid.name || '-'}</div>
+      {effects.length > 0 && (
+        <div className="status-badge">
+          {effects.map(e => `${effectLabel(e)} (${e.remaining_rounds})`).join(' · ')}
+        </div>
+      )}
+      {upcoming.some(e => e.kind === 'stun') && (
+        <div className="status-badge">Stunned next round</div>
+      )}
       <div className="row-start">
         {imgSrc && (
           <img src={imgSrc} alt={hybrid.generated_name || hybrid.name} width={96} height={96} className="entity-image" onError={(e)=>{ (e.currentTarget as HTMLImageElement).style.visibility = 'hidden'; }} />
@@ -1,6 +1,7 @@
 import React, { useEffect, useRef, useState } from 'react';
 import { useParams, useNavigate } from 'react-router-dom';
 import HybridCreation from './HybridCreation';
+import { maxPlayers } from './utils/teams';
 import Timer from './Timer';
 import { useGame } from './hooks/useGame';
 import { Button, WaitingAnimation, Avatar } from './ui';
@@ -16,6 +17,7 @@ const GameRoom: React.FC = () => {
   const [timeLeftMs, setTimeLeftMs] = useState<number | null>(null);
   const [publicGamesTTLSeconds, setPublicGamesTTLSeconds] = useState<number | null>(null);
   const [submitting, setSubmitting] = useState(false);
+  const [botDifficulty, setBotDifficulty] = useState<'easy' | 'hard' | 'expert'>('easy');
   const actingRef = useRef(false);
   const hasLeftRef = useRef(false);
   const toBoardRef = useRef(false);
@@ -151,6 +153,27 @@ const GameRoom: React.FC = () => {
     }
   };
 
+  const handleAddBot = async () => {
+    try {
+      if (actingRef.current) return;
+      actingRef.current = true;
+      const response = await apiFetch(`${constants.API_GAMES}/${gameCode}/bots`, {
+        method: 'POST',
+        headers: { [constants.HEADER_CONTENT_TYPE]: constants.CONTENT_TYPE_JSON },
+        body: JSON.stringify({ difficulty: botDifficulty }),
+      });
+      if (!response.ok) {
+        const data = await response.json().catch(() => ({}));
+        throw new Error(data.error || 'Failed to add bot');
+      }
+    } catch (err: any) {
+      alert(`Error adding bot: ${err.message}`);
+      console.error(err);
+    } finally {
+      actingRef.current = false;
+    }
+  };
+
   const effectiveError = gameError;
   if (effectiveError) {
     return <div>Error: {effectiveError}</div>;
@@ -162,7 +185,8 @@ const GameRoom: React.FC = () => {
 
   const isCreator = game.players.length > 0 && (game.players[0].player_email || '') === currentPlayerEmail;
   const currentPlayer: Player | undefined = game.players.find(p => (p.player_email || '') === currentPlayerEmail);
-  const allReady = game.players.length === 2 && game.players.every(p => p.has_created);
+  const seats = maxPlayers(game);
+  const allReady = game.players.length === seats && game.players.every(p => p.has_created);
 
   
 
@@ -211,7 +235,7 @@ const GameRoom: React.FC = () => {
 
         <h4>Status: {game.status}</h4>
 
-        <h4>Players ({game.players?.length || 0} / 2)</h4>
+        <h4>Players ({game.players?.length || 0} / {seats})</h4>
         <ul className="list-reset players-list">
           {(() => {
             // Try to read the currently-logged user from localStorage to show their profile picture
@@ -245,8 +269,10 @@ const GameRoom: React.FC = () => {
                     <div>
                       <strong>{player.player_name || `Player ${player.ID}`}</strong>
                       {player.player_email === currentPlayerEmail && <span className="muted-sm"> (You)</span>}
+                      {player.bot_difficulty && <span className="muted-sm"> (bot, {player.bot_difficulty})</span>}
                     </div>
                     <div className="muted-sm">Hybrids created: {player.has_created ? 'Yes' : 'No'}</div>
+                    {game.mode === 'teams' && <div className="muted-sm">Team {(player.team ?? 0) + 1}</div>}
                   </div>
                 </div>
               </li>
@@ -255,10 +281,21 @@ const GameRoom: React.FC = () => {
         </ul>
 
         {game.status === 'waiting_for_players' && currentPlayer && !currentPlayer.has_created && (
-          <HybridCreation gameCode={gameCode!} onCreated={() => {}} ttlExpired={timeLeftMs !== null && timeLeftMs <= 0} />
+          <HybridCreation gameCode={gameCode!} onCreated={() => {}} ttlExpired={timeLeftMs !== null && timeLeftMs <= 0} hybridCount={game.hybrid_count || 2} />
+        )}
+
+        {currentPlayer && game.players.length < seats && game.status === 'waiting_for_players' && (
+          <div className="row-start mt-6">
+            <select value={botDifficulty} onChange={(e) => setBotDifficulty(e.target.value as 'easy' | 'hard' | 'expert')} aria-label="Bot difficulty">
+              <option value="easy">Easy bot</option>
+              <option value="hard">Hard bot</option>
+              <option value="expert">Expert bot</option>
+            </select>
+            <Button onClick={handleAddBot}>Add bot</Button>
+          </div>
         )}
 
-        {isCreator && game.players.length === 2 && game.status === 'waiting_for_players' && (
+        {isCreator && game.players.length === seats && game.status === 'waiting_for_players' && (
           <Button onClick={handleStartGame} disabled={!allReady || submitting || (timeLeftMs !== null && timeLeftMs <= 0)}>
             {allReady ? 'Start Game' : 'Waiting hybrids...'}
           </Button>
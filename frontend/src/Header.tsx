@@ -22,8 +22,9 @@ const Header: React.FC<Props> = ({ user, onLogout, showProfileOption }) => {
   const stats = usePlayerStats(user?.email || null);
   const wins = stats?.Wins ?? 0;
   const gamesPlayed = stats?.GamesPlayed ?? 0;
+  const draws = stats?.Draws ?? 0;
   const resigns = stats?.Resignations ?? 0;
-  const defeats = Math.max(0, gamesPlayed - wins - resigns);
+  const defeats = Math.max(0, gamesPlayed - wins - draws - resigns);
 
   return (
     <header className="page-header shared-header">
@@ -34,7 +35,7 @@ const Header: React.FC<Props> = ({ user, onLogout, showProfileOption }) => {
           </span>
         </h3>
         <div className="header-stats">
-          Wins: {wins} · Defeats: {defeats} · Resignations: {resigns}
+          Wins: {wins} · Draws: {draws} · Defeats: {defeats} · Resignations: {resigns}
         </div>
       </div>
       <div className="header-right">
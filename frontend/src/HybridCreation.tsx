@@ -1,5 +1,5 @@
 import React, { useEffect, useMemo, useRef, useState } from 'react';
-import { Entity } from './types';
+import { Entity, Synergy } from './types';
 import { apiFetch } from './api';
 import * as constants from './constants';
 import { entityAssetUrl } from './utils/keys';
@@ -11,6 +11,8 @@ interface Props {
   onCreated?: () => void;
   // When true, the server-side public-games TTL expired and creation must be disabled
   ttlExpired?: boolean;
+  // Number of hybrids the player must create (game.hybrid_count)
+  hybridCount?: number;
 }
 
 interface HybridSpecState {
@@ -18,12 +20,13 @@ interface HybridSpecState {
   selectedEntityId?: number;
 }
 
-const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = false }) => {
+const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = false, hybridCount = 2 }) => {
   const [entities, setEntities] = useState<Entity[]>([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
-  const [h1, setH1] = useState<HybridSpecState>({ entityIds: [], selectedEntityId: undefined });
-  const [h2, setH2] = useState<HybridSpecState>({ entityIds: [], selectedEntityId: undefined });
+  const [specs, setSpecs] = useState<HybridSpecState[]>(() =>
+    Array.from({ length: hybridCount }, () => ({ entityIds: [], selectedEntityId: undefined })),
+  );
   const [submitting, setSubmitting] = useState(false);
   const actingRef = useRef(false);
   const [showHelp, setShowHelp] = useState(false);
@@ -44,11 +47,12 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
     fetchEntities();
   }, []);
 
-  const usedIds = useMemo(() => new Set([...h1.entityIds, ...h2.entityIds]), [h1, h2]);
-  const toggleAnimalSelection = (target: 'h1' | 'h2', id: number) => {
+  const usedIds = useMemo(() => new Set(specs.flatMap((s) => s.entityIds)), [specs]);
+  const setSpec = (target: number, spec: HybridSpecState) =>
+    setSpecs((prev) => prev.map((s, i) => (i === target ? spec : s)));
+  const toggleAnimalSelection = (target: number, id: number) => {
     if (submitting || ttlExpired) return;
-    const src = target === 'h1' ? h1 : h2;
-    const setter = target === 'h1' ? setH1 : setH2;
+    const src = specs[target];
     const isUsedElsewhere = usedIds.has(id) && !src.entityIds.includes(id);
     if (isUsedElsewhere) return;
     const picked = src.entityIds.includes(id)
@@ -61,15 +65,43 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
     if (updated.selectedEntityId && !updated.entityIds.includes(updated.selectedEntityId)) {
       updated.selectedEntityId = undefined;
     }
-    setter(updated);
+    setSpec(target, updated);
   };
 
-  const isValidSelection =
-    h1.entityIds.length >= 2 && h1.entityIds.length <= 3 &&
-    h2.entityIds.length >= 2 && h2.entityIds.length <= 3 &&
-    h1.entityIds.every((id) => !h2.entityIds.includes(id)) &&
-    !!h1.selectedEntityId &&
-    !!h2.selectedEntityId;
+  // Mirrors game.Synergy.Matches on the backend.
+  const matchedSynergies = (ids: number[]): Synergy[] => {
+    const picked = entities.filter((e) => ids.includes(e.ID));
+    const lower = (s: string) => s.toLowerCase();
+    const all = new Map<string, Synergy>();
+    picked.forEach((e) => (e.synergies || []).forEach((s) => all.set(s.key, s)));
+    return Array.from(all.values()).filter((s) => {
+      const names = picked.map((e) => lower(e.name));
+      if (!(s.entities || []).every((n) => names.includes(lower(n)))) return false;
+      if (s.tag) {
+        const tagged = picked.filter((e) => (e.tags || []).some((t) => lower(t) === lower(s.tag!))).length;
+        if (tagged < (s.min_count || 2)) return false;
+      }
+      return true;
+    });
+  };
+
+  const synergyList = (ids: number[]) => {
+    const list = matchedSynergies(ids);
+    if (list.length === 0) return null;
+    return (
+      <div className="muted-sm mt-4">
+        Synergies: {list.map((s) => (
+          <span key={s.key} title={s.description}>{s.name} </span>
+        ))}
+      </div>
+    );
+  };
+
+  // Entities are never shared (see toggleAnimalSelection), so only sizes
+  // and ability choices need checking.
+  const isValidSelection = specs.every(
+    (s) => s.entityIds.length >= 2 && s.entityIds.length <= 3 && !!s.selectedEntityId,
+  );
 
   
 
@@ -83,8 +115,7 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
         method: 'POST',
         headers: { [constants.HEADER_CONTENT_TYPE]: constants.CONTENT_TYPE_JSON },
         body: JSON.stringify({
-          hybrid1: { entity_ids: h1.entityIds, selected_entity_id: h1.selectedEntityId },
-          hybrid2: { entity_ids: h2.entityIds, selected_entity_id: h2.selectedEntityId },
+          hybrids: specs.map((s) => ({ entity_ids: s.entityIds, selected_entity_id: s.selectedEntityId })),
         }),
       });
       if (!res.ok) {
@@ -107,8 +138,8 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
     return entityAssetUrl(name);
   };
 
-  const animalCard = (a: Entity, target: 'h1' | 'h2') => {
-    const src = target === 'h1' ? h1 : h2;
+  const animalCard = (a: Entity, target: number) => {
+    const src = specs[target];
     const disabled = usedIds.has(a.ID) && !src.entityIds.includes(a.ID);
     const selected = src.entityIds.includes(a.ID);
     const canSelect = !disabled && (selected || src.entityIds.length < 3);
@@ -135,6 +166,9 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
               HP {a.pv} | ATK {a.atq} | DEF {a.def} | AGI {a.agi} | ENE {a.ene} | VIG {a.vigor_cost ?? '-'}
             </div>
             <div className="muted-sm">{a.skill?.name} (Cost {a.skill?.cost})</div>
+            {a.passive && (
+              <div className="muted-sm" title={a.passive.description}>Passive: {a.passive.name}</div>
+            )}
             {(() => {
               const isPicked = src.entityIds.includes(a.ID);
               return (
@@ -145,10 +179,7 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
                         type="radio"
                         name={`${target}-selected-ability`}
                         checked={src.selectedEntityId === a.ID}
-                        onChange={() => {
-                          const setter = target === 'h1' ? setH1 : setH2;
-                          setter({ ...src, selectedEntityId: a.ID });
-                        }}
+                        onChange={() => setSpec(target, { ...src, selectedEntityId: a.ID })}
                         disabled={!selected}
                       />
                       <span className={needsAbilitySelection ? 'blink-text' : ''}>Use this entity's special ability</span>
@@ -164,7 +195,7 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
     );
   };
 
-  const grid = (target: 'h1' | 'h2') => (
+  const grid = (target: number) => (
     <div className="entities-grid">
       {entities.map((a) => animalCard(a, target))}
     </div>
@@ -179,18 +210,18 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
         <h3 className="no-margin">Create Your Hybrids</h3>
       </div>
       <div className="hybrid-creation-grid">
-        <section>
-          <h4>Hybrid 1</h4>
-          {grid('h1')}
-          <div className="muted-sm mt-4">Pick 2 to 3 entities and choose 1 special ability among them</div>
-          
-        </section>
-        <section>
-          <h4>Hybrid 2</h4>
-          {grid('h2')}
-          <div className="muted-sm mt-4">Pick 2 to 3 entities (no overlap with Hybrid 1) and choose 1 special ability</div>
-          
-        </section>
+        {specs.map((spec, idx) => (
+          <section key={idx}>
+            <h4>Hybrid {idx + 1}</h4>
+            {grid(idx)}
+            <div className="muted-sm mt-4">
+              {idx === 0
+                ? 'Pick 2 to 3 entities and choose 1 special ability among them'
+                : 'Pick 2 to 3 entities (no overlap with the other hybrids) and choose 1 special ability'}
+            </div>
+            {synergyList(spec.entityIds)}
+          </section>
+        ))}
         <Button onClick={handleSubmit} disabled={!isValidSelection || submitting || ttlExpired}>
           {submitting ? 'Creating…' : 'Create Hybrids'}
         </Button>
@@ -204,7 +235,7 @@ const HybridCreation: React.FC<Props> = ({ gameCode, onCreated, ttlExpired = fal
           <div className="text-left">
             <ul className="help-list">
               <li className="mb-8">Pick 2–3 entities for each hybrid.</li>
-              <li className="mb-8">Create two different hybrids — hybrids cannot share the same entity.</li>
+              <li className="mb-8">Create {hybridCount} different hybrid{hybridCount === 1 ? '' : 's'} — hybrids cannot share the same entity.</li>
               <li className="mb-8">For each hybrid, choose one of the selected entities to enable its special ability.</li>
               <li>Tap <strong>"Create Hybrids"</strong> to save.</li>
             </ul>
@@ -25,6 +25,8 @@ const Lobby: React.FC<LobbyProps> = ({ user, onLogout }) => {
   const [gameName, setGameName] = useState('');
   const [gameDescription, setGameDescription] = useState('');
   const [isPrivate, setIsPrivate] = useState(false);
+  const [hybridCount, setHybridCount] = useState(2);
+  const [mode, setMode] = useState<'duel' | 'teams'>('duel');
   const [submitting, setSubmitting] = useState(false);
   const JOIN_CODE_LENGTH = 8;
   const navigate = useNavigate();
@@ -87,6 +89,8 @@ const Lobby: React.FC<LobbyProps> = ({ user, onLogout }) => {
           name: gameName,
           description: gameDescription,
           private: isPrivate,
+          hybrid_count: hybridCount,
+          mode,
           player_name: user.name,
           player_email: user.email || '',
         }),
@@ -174,6 +178,31 @@ const Lobby: React.FC<LobbyProps> = ({ user, onLogout }) => {
               />
               <label htmlFor="privateGame" className="ml-10">Private Game</label>
             </div>
+            <div className="mb-20">
+              <label htmlFor="hybridCount">Hybrids per player</label>
+              <select
+                id="hybridCount"
+                className="ml-10"
+                value={hybridCount}
+                onChange={(e) => setHybridCount(Number(e.target.value))}
+              >
+                {[1, 2, 3, 4, 5].map((n) => (
+                  <option key={n} value={n}>{n}</option>
+                ))}
+              </select>
+            </div>
+            <div className="mb-20">
+              <label htmlFor="gameMode">Mode</label>
+              <select
+                id="gameMode"
+                className="ml-10"
+                value={mode}
+                onChange={(e) => setMode(e.target.value as 'duel' | 'teams')}
+              >
+                <option value="duel">Duel (1v1)</option>
+                <option value="teams">Teams (2v2)</option>
+              </select>
+            </div>
             <Button onClick={createGame} disabled={submitting || gameName.trim().length < 5} className="full-width">
               Create Game
             </Button>
@@ -240,6 +269,7 @@ const Lobby: React.FC<LobbyProps> = ({ user, onLogout }) => {
             <div>#</div>
             <div>Name</div>
             <div>Wins</div>
+            <div>Draws</div>
             <div>Defeats</div>
             <div>Resignations</div>
           </div>
@@ -247,14 +277,16 @@ const Lobby: React.FC<LobbyProps> = ({ user, onLogout }) => {
             leaderboard.map((p, idx) => {
               const gp = (p as any).GamesPlayed ?? (p as any).games_played ?? 0;
               const wins = (p as any).Wins ?? (p as any).wins ?? 0;
+              const draws = (p as any).Draws ?? (p as any).draws ?? 0;
               const resign = (p as any).Resignations ?? (p as any).resignations ?? 0;
-              const defeatsRow = Math.max(0, gp - wins - resign);
+              const defeatsRow = Math.max(0, gp - wins - draws - resign);
               const name = (p as any).PlayerName ?? (p as any).player_name ?? 'Unknown';
               return (
                 <div key={(p as any).ID ?? idx} className="rank-list">
                   <div>{idx + 1}</div>
                   <div>{name}</div>
                   <div>{wins}</div>
+                  <div>{draws}</div>
                   <div>{defeatsRow}</div>
                   <div>{resign}</div>
                 </div>
@@ -15,7 +15,7 @@ const ProfilePage: React.FC<Props> = ({ user, onLogout, onUserUpdate }) => {
   const navigate = useNavigate();
   const [name, setName] = useState(user?.name || '');
   const [loading, setLoading] = useState(false);
-  const [stats, setStats] = useState<{ GamesPlayed: number; Wins: number; Resignations: number } | null>(null);
+  const [stats, setStats] = useState<{ GamesPlayed: number; Wins: number; Draws: number; Resignations: number } | null>(null);
   const [error, setError] = useState<string | null>(null);
   const [nameError, setNameError] = useState<string | null>(null);
 
@@ -28,7 +28,7 @@ const ProfilePage: React.FC<Props> = ({ user, onLogout, onUserUpdate }) => {
           return;
         }
         const data = await res.json();
-        setStats({ GamesPlayed: data.GamesPlayed ?? data.games_played ?? 0, Wins: data.Wins ?? data.wins ?? 0, Resignations: data.Resignations ?? data.resignations ?? 0 });
+        setStats({ GamesPlayed: data.GamesPlayed ?? data.games_played ?? 0, Wins: data.Wins ?? data.wins ?? 0, Draws: data.Draws ?? data.draws ?? 0, Resignations: data.Resignations ?? data.resignations ?? 0 });
       } catch (e) {
         console.error('Failed to load stats', e);
       }
@@ -103,6 +103,7 @@ const ProfilePage: React.FC<Props> = ({ user, onLogout, onUserUpdate }) => {
           <thead>
             <tr>
               <th>Wins</th>
+              <th>Draws</th>
               <th>Defeats</th>
               <th>Resignations</th>
             </tr>
@@ -110,7 +111,8 @@ const ProfilePage: React.FC<Props> = ({ user, onLogout, onUserUpdate }) => {
           <tbody>
             <tr>
               <td>{stats ? stats.Wins : '-'}</td>
-              <td>{stats ? Math.max(0, (stats.GamesPlayed ?? 0) - (stats.Wins ?? 0) - (stats.Resignations ?? 0)) : '-'}</td>
+              <td>{stats ? stats.Draws : '-'}</td>
+              <td>{stats ? Math.max(0, (stats.GamesPlayed ?? 0) - (stats.Wins ?? 0) - (stats.Draws ?? 0) - (stats.Resignations ?? 0)) : '-'}</td>
               <td>{stats ? stats.Resignations : '-'}</td>
             </tr>
           </tbody>
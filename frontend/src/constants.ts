@@ -9,6 +9,18 @@ export const API_PUBLIC_GAMES = `${API_PREFIX}/public-games`;
 export const API_LEADERBOARD = `${API_PREFIX}/leaderboard`;
 export const API_GAMES = `${API_PREFIX}/games`;
 export const API_GAMES_JOIN = `${API_GAMES}/join`;
+// Event names sent by the game update stream (`${API_GAMES}/:code/events`).
+export const GAME_EVENT_TYPES = [
+  'game_updated',
+  'game_started',
+  'action_submitted',
+  'round_resolved',
+  'reserve_entered',
+  'game_finished',
+] as const;
+// While the event stream is open the game is still polled every
+// LIVE_POLL_EVERY intervals.
+export const LIVE_POLL_EVERY = 10;
 export const API_PLAYER_STATS = `${API_PREFIX}/player-stats`;
 // The Google OAuth callback is mounted at the root `/auth/...` path so
 // external OAuth redirects can reach it directly (not under `/api`).
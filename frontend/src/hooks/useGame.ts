@@ -44,13 +44,47 @@ export function useGame(gameId?: string | null, intervalMs = 3000) {
       }
     })();
 
+    // Push updates: while the event stream is open the server sends the
+    // new state on every game event, so polling falls back to a slow pace
+    // that only catches changes without an event (or dropped updates) and
+    // is back to normal when the stream is unavailable or reconnecting.
+    let live = false;
+    let ticks = 0;
+    let source: EventSource | null = null;
+    if (typeof EventSource !== 'undefined') {
+      source = new EventSource(`${constants.API_GAMES}/${gameId}/events`, { withCredentials: true });
+      const onUpdate = (ev: MessageEvent) => {
+        if (!mounted) return;
+        try {
+          const data = JSON.parse(ev.data);
+          if (data && data.game) {
+            setGame(data.game);
+            setError(null);
+          }
+        } catch (e) {
+          // ignore malformed events; polling catches up
+        }
+      };
+      for (const type of constants.GAME_EVENT_TYPES) {
+        source.addEventListener(type, onUpdate as EventListener);
+      }
+      source.onopen = () => {
+        live = true;
+      };
+      source.onerror = () => {
+        live = false;
+      };
+    }
+
     const id = window.setInterval(() => {
-      fetchGame();
+      ticks++;
+      if (!live || ticks % constants.LIVE_POLL_EVERY === 0) fetchGame();
     }, intervalMs);
 
     return () => {
       mounted = false;
       window.clearInterval(id);
+      if (source) source.close();
     };
   }, [fetchGame, gameId, intervalMs]);
 
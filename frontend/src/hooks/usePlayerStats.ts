@@ -2,7 +2,7 @@ import { useEffect, useState } from 'react';
 import { apiFetch } from '../api';
 import * as constants from '../constants';
 
-export interface PlayerStats { GamesPlayed: number; Wins: number; Resignations: number }
+export interface PlayerStats { GamesPlayed: number; Wins: number; Draws: number; Resignations: number }
 
 export function usePlayerStats(email?: string | null) {
   const [stats, setStats] = useState<PlayerStats | null>(null);
@@ -16,7 +16,7 @@ export function usePlayerStats(email?: string | null) {
         if (!res.ok) return;
         const data = await res.json();
         if (!mounted) return;
-        setStats({ GamesPlayed: data.GamesPlayed ?? data.games_played ?? 0, Wins: data.Wins ?? data.wins ?? 0, Resignations: data.Resignations ?? data.resignations ?? 0 });
+        setStats({ GamesPlayed: data.GamesPlayed ?? data.games_played ?? 0, Wins: data.Wins ?? data.wins ?? 0, Draws: data.Draws ?? data.draws ?? 0, Resignations: data.Resignations ?? data.resignations ?? 0 });
       } catch (e) {
         // ignore
       }
@@ -0,0 +1,24 @@
+import { useEffect, useState } from 'react';
+import { apiFetch } from '../api';
+import * as constants from '../constants';
+import { DEFAULT_RULES, Rules } from '../types';
+
+// useRules loads the combat ruleset in effect from the backend config.
+export function useRules() {
+  const [rules, setRules] = useState<Rules>(DEFAULT_RULES);
+  useEffect(() => {
+    let mounted = true;
+    const load = async () => {
+      try {
+        const res = await apiFetch(constants.API_CONFIG);
+        if (!res.ok) return;
+        const body = await res.json();
+        if (!mounted || !body || typeof body.rules !== 'object' || !body.rules) return;
+        setRules({ ...DEFAULT_RULES, ...body.rules });
+      } catch {}
+    };
+    load();
+    return () => { mounted = false; };
+  }, []);
+  return rules;
+}
@@ -11,9 +11,42 @@ export interface Entity {
     name: string;
     description?: string;
     cost?: number;
+    // rounds the ability is unavailable after being used
+    cooldown?: number;
     key?: string;
     effect?: any;
   };
+  // Tags used to match synergies (e.g. "predator").
+  tags?: string[];
+  // Synergies this entity can contribute to (GET /api/entities only).
+  synergies?: Synergy[];
+  // Always-on trait applied to every hybrid that includes this entity.
+  passive?: {
+    name: string;
+    description?: string;
+    key?: string;
+    effect?: any;
+  };
+}
+
+// Combination bonus granted to hybrids whose entities match it: all named
+// entities present and, when tag is set, at least min_count tagged ones.
+export interface Synergy {
+  name: string;
+  description?: string;
+  key: string;
+  entities?: string[];
+  tag?: string;
+  min_count?: number;
+  bonus: {
+    hit_points?: number;
+    attack?: number;
+    defense?: number;
+    agility?: number;
+    energy?: number;
+    defense_per_round?: number;
+    energy_per_round?: number;
+  };
 }
 
 // Canonical entity names used across the frontend.
@@ -51,11 +84,29 @@ export interface Hybrid {
   current_vig?: number;
   is_active: boolean;
   is_defeated: boolean;
+  // synergies matched when the hybrid was built
+  synergies?: Synergy[] | null;
+  // remaining cooldown (rounds, counting the current one) by ability entity ID
+  ability_cooldowns?: Record<string, number> | null;
+  // true once the hybrid has entered the arena at least once
+  revealed?: boolean;
   // combat state (optional from backend)
-  stunned_until_round?: number;
+  status_effects?: StatusEffect[];
   last_action?: string;
 }
 
+// Temporary buff/debuff/control effect carried by a hybrid. It applies
+// from start_round while remaining_rounds > 0.
+export interface StatusEffect {
+  kind: string;
+  magnitude: number;
+  source_skill?: string;
+  source_name?: string;
+  start_round: number;
+  remaining_rounds: number;
+  stacking: string;
+}
+
 export interface Player {
   ID: number;
   player_name: string;
@@ -64,14 +115,71 @@ export interface Player {
   has_submitted_action?: boolean;
   pending_action_type?: string;
   pending_action_entity_id?: number;
+  pending_switch_index?: number | null;
+  // reserve brought in when the active hybrid is defeated (null = first available)
+  next_reserve_index?: number | null;
+  // side in team games (see utils/teams.ts)
+  team?: number;
+  // enemy player (index in game.players) targeted by the pending action
+  pending_target_index?: number | null;
+  // difficulty of a server-controlled player; absent for humans
+  bot_difficulty?: 'easy' | 'hard' | 'expert';
   hybrids: Hybrid[];
 }
 
+// Structured combat log entry emitted by the engine for each resolved round.
+export interface RoundEvent {
+  type: string;
+  round: number;
+  player_index: number;
+  player: string;
+  hybrid?: string;
+  target_index?: number;
+  target?: string;
+  target_hybrid?: string;
+  action?: string;
+  skill?: string;
+  skill_key?: string;
+  effect?: string;
+  percent?: number;
+  multiplier?: number;
+  divisor?: number;
+  duration?: number;
+  energy?: number;
+  vigor?: number;
+  defense?: number;
+  amount?: number;
+  vulnerable?: boolean;
+  // not_enough_energy or on_cooldown (ability_failed events)
+  reason?: string;
+  // condition that held for events produced by a conditional skill effect
+  condition?: {
+    opponent_action?: string;
+    self_hp_below_percent?: number;
+    opponent_hp_below_percent?: number;
+  };
+  damage?: {
+    attack: number;
+    agility_bonus?: number;
+    defense: number;
+    defense_ignored?: number;
+    ignored_percent?: number;
+    defense_modifiers?: string[];
+    base: number;
+    multipliers?: { source: string; factor: number }[];
+    final: number;
+  };
+}
+
 export interface Game {
   ID: number;
   name: string;
   description: string;
   private: boolean;
+  // hybrids each player creates (team size)
+  hybrid_count?: number;
+  // 'duel' (default) or 'teams' (2v2)
+  mode?: 'duel' | 'teams';
   join_code: string;
   players: Player[];
   current_turn: string;
@@ -80,9 +188,46 @@ export interface Game {
   phase?: 'planning' | 'resolving' | 'resolved';
   status: string;
   winner?: string;
+  winning_team?: number | null;
+  draw?: boolean;
+  sudden_death?: boolean;
   message?: string;
   last_round_summary?: string;
+  last_round_events?: RoundEvent[];
   created_at: string;
   // ISO timestamp indicating when the current planning phase expires
   action_deadline?: string;
 }
+
+// Combat ruleset in effect on the server (GET /api/config -> rules).
+export interface Rules {
+  energy_per_round: number;
+  rest_vigor: number;
+  rest_energy: number;
+  defend_multiplier: number;
+  vulnerable_multiplier: number;
+  zero_vigor_multiplier: number;
+  base_vigor: number;
+  fatigue: { start_round: number; defense_loss: number[] };
+  critical: { base_chance: number; chance_per_agility: number; max_chance: number; multiplier: number };
+  dodge: { base_chance: number; chance_per_agility: number; max_chance: number };
+  // all_usable lets hybrids use every base entity's skill; non-primary
+  // skills cost secondary_energy_surcharge extra Energy.
+  abilities?: { all_usable: boolean; secondary_energy_surcharge: number };
+}
+
+// Defaults matching the server's built-in ruleset, used until the config
+// has been loaded.
+export const DEFAULT_RULES: Rules = {
+  energy_per_round: 1,
+  rest_vigor: 2,
+  rest_energy: 2,
+  defend_multiplier: 1.5,
+  vulnerable_multiplier: 1.25,
+  zero_vigor_multiplier: 0.5,
+  base_vigor: 3,
+  fatigue: { start_round: 3, defense_loss: [1, 2, 3] },
+  critical: { base_chance: 0, chance_per_agility: 5, max_chance: 40, multiplier: 1.5 },
+  dodge: { base_chance: 0, chance_per_agility: 4, max_chance: 30 },
+  abilities: { all_usable: false, secondary_energy_surcharge: 1 },
+};
@@ -0,0 +1,20 @@
+import { Game } from '../types';
+
+// Mirrors game.Game.MaxPlayers on the backend.
+export function maxPlayers(game: Game): number {
+  return game.mode === 'teams' ? 4 : 2;
+}
+
+// Mirrors game.Game.TeamOf: duel players are their own team.
+export function teamOf(game: Game, idx: number): number {
+  return game.mode === 'teams' ? game.players[idx]?.team ?? 0 : idx;
+}
+
+// Indexes of the enemies of player idx that still have a hybrid in the arena.
+export function enemiesInPlay(game: Game, idx: number): number[] {
+  const team = teamOf(game, idx);
+  return game.players
+    .map((p, i) => ({ p, i }))
+    .filter(({ p, i }) => teamOf(game, i) !== team && p.hybrids?.some((h) => h.is_active && !h.is_defeated))
+    .map(({ i }) => i);
+}